@@ -16,6 +16,43 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/deployments/dry-run": {
+            "post": {
+                "description": "runs the transformation and validation of a deployment without deploying it; returns the resulting xml, the applied rewrites and warnings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deployment"
+                ],
+                "summary": "deployment dry-run",
+                "parameters": [
+                    {
+                        "description": "deployment; same format as the payload of the cmd/deployment mqtt topic",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DeploymentDryRunResult"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    }
+                }
+            }
+        },
         "/event-descriptions": {
             "get": {
                 "description": "finds event descriptions for event-worker",
@@ -61,6 +98,35 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "model.DeploymentDryRunResult": {
+            "type": "object",
+            "properties": {
+                "deployment_id": {
+                    "type": "string"
+                },
+                "rewrites": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "validation_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ValidationError"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "xml": {
+                    "type": "string"
+                }
+            }
+        },
         "model.EventDesc": {
             "type": "object",
             "properties": {
@@ -121,6 +187,20 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ValidationError": {
+            "type": "object",
+            "properties": {
+                "element_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Attribute": {
             "type": "object",
             "properties": {
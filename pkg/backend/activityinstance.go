@@ -0,0 +1,35 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	model "github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+const activityInstanceTopic = "activity-instance"
+
+func (this *Client) SendActivityInstanceUpdate(instance model.HistoricActivityInstance) error {
+	return this.sendObj(this.getStateTopic(activityInstanceTopic), instance)
+}
+
+func (this *Client) SendActivityInstanceDelete(id string) error {
+	return this.sendStr(this.getStateTopic(activityInstanceTopic, "delete"), id)
+}
+
+func (this *Client) SendActivityInstanceKnownIds(ids []string) error {
+	return this.sendObj(this.getStateTopic(activityInstanceTopic, "known"), ids)
+}
@@ -20,6 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	eventmodel "github.com/SENERGY-Platform/event-worker/pkg/model"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
@@ -29,10 +32,15 @@ import (
 )
 
 type Client struct {
-	mqtt    paho.Client
-	debug   bool
-	config  configuration.Config
-	handler Handler
+	mqtt       paho.Client
+	debug      bool
+	config     configuration.Config
+	handler    Handler
+	dispatcher *Dispatcher
+	debouncer  *Debouncer              //nil if state_update_debounce is disabled
+	limiters   map[string]*RateLimiter //by state topic entity
+	seq        *atomic.Int64           //shared by all sources
+	source     string                  //see model.StateSource*
 }
 
 type Handler interface {
@@ -41,15 +49,38 @@ type Handler interface {
 	DeleteDeployment(id string) error
 	StartDeployment(id string, businessKey string, parameter map[string]interface{}) error
 	CreateDeployment(payload model.FogDeploymentMessage) (id string, err error)
+	DryRunDeployment(payload model.FogDeploymentMessage) model.DeploymentDryRunResult
 	UpdateDeploymentEvents(camundaDeploymentId string, descriptions []eventmodel.EventDesc, id map[string]string, localId map[string]string) error
+	GetDeploymentId(camundaDeploymentId string) string //id of the deployment message the camunda deployment was created from; used to order deployment commands
 	HandleIncident(incident camundamodel.Incident) error
+	EvaluateDecision(decisionKey string, variables map[string]interface{}) ([]map[string]camundamodel.Variable, error)
+	CompleteUserTask(taskId string, variables map[string]interface{}) error
 }
 
 func New(config configuration.Config, ctx context.Context, handler Handler) (*Client, error) {
 	client := &Client{
-		config:  config,
-		debug:   config.Debug,
-		handler: handler,
+		config:     config,
+		debug:      config.Debug,
+		handler:    handler,
+		dispatcher: NewDispatcher(ctx, int(config.CommandWorkerCount), int(config.CommandQueueLimit)),
+		limiters:   map[string]*RateLimiter{},
+		seq:        &atomic.Int64{},
+		source:     model.StateSourceTrigger,
+	}
+	client.seq.Store(time.Now().UnixMicro())
+	if config.StateUpdateDebounce != "" && config.StateUpdateDebounce != "-" {
+		window, err := time.ParseDuration(config.StateUpdateDebounce)
+		if err != nil {
+			return nil, err
+		}
+		client.debouncer = NewDebouncer(window)
+	}
+	for entity, limit := range config.StateRateLimits {
+		interval, err := time.ParseDuration(limit)
+		if err != nil {
+			return nil, err
+		}
+		client.limiters[entity] = NewRateLimiter(interval)
 	}
 	options := paho.NewClientOptions().
 		SetPassword(config.MqttPw).
@@ -82,52 +113,121 @@ func New(config configuration.Config, ctx context.Context, handler Handler) (*Cl
 		client.mqtt.Disconnect(0)
 	}()
 
+	client.startDispatcherStateReport(ctx)
+
 	return client, nil
 }
 
 func (this *Client) subscribe() {
-	this.mqtt.Subscribe(this.getDeploymentTopic(), 2, func(client paho.Client, message paho.Message) {
-		if this.debug {
-			log.Println("DEBUG: receive", message.Topic(), string(message.Payload()))
-		}
-		go this.handleDeploymentCommand(message)
-	})
-	this.mqtt.Subscribe(this.getDeploymentDeleteTopic(), 2, func(client paho.Client, message paho.Message) {
+	this.subscribeCommand(this.getDeploymentTopic(), keyByJsonField(deploymentTopic, "id"), (*Client).handleDeploymentCommand)
+	this.subscribeCommand(this.getDeploymentDryRunTopic(), unordered, (*Client).handleDeploymentDryRunCommand)
+	this.subscribeCommand(this.getDeploymentDeleteTopic(), this.keyByCamundaDeployment(keyByPayload(deploymentTopic)), (*Client).handleDeploymentDeleteCommand)
+	this.subscribeCommand(this.getProcessDeploymentStartTopic(), this.keyByCamundaDeployment(keyByJsonField(deploymentTopic, "deployment_id")), (*Client).handleDeploymentStartCommand)
+	this.subscribeCommand(this.getProcessEventUpdateTopic(), this.keyByCamundaDeployment(keyByJsonField(deploymentTopic, "camunda_deployment_id")), (*Client).handleEventUpdateCommand)
+	this.subscribeCommand(this.getProcessStopTopic(), keyByPayload(processInstanceTopic), (*Client).handleProcessStopCommand)
+	this.subscribeCommand(this.getProcessHistoryDeleteTopic(), keyByPayload(processInstanceTopic), (*Client).handleProcessHistoryDeleteCommand)
+	this.subscribeCommand(this.getProcessIncidentTopic(), keyByJsonField(processInstanceTopic, "process_instance_id"), (*Client).handleProcessIncident)
+	this.subscribeCommand(this.getDecisionEvaluateTopic(), unordered, (*Client).handleDecisionEvaluateCommand)
+	this.subscribeCommand(this.getUserTaskCompleteTopic(), keyByJsonField(userTaskTopic, "task_id"), (*Client).handleUserTaskCompleteCommand)
+}
+
+// subscribeCommand handles messages of the topic with the dispatcher
+// messages with the same key (e.g. the same deployment) are handled in the order they were received
+// states sent by the handler are marked with the command source
+func (this *Client) subscribeCommand(topic string, getKey func(payload []byte) string, handler func(client *Client, message paho.Message)) {
+	command := this.WithSource(model.StateSourceCommand)
+	this.mqtt.Subscribe(topic, 2, func(client paho.Client, message paho.Message) {
 		if this.debug {
 			log.Println("DEBUG: receive", message.Topic(), string(message.Payload()))
 		}
-		go this.handleDeploymentDeleteCommand(message)
-	})
-	this.mqtt.Subscribe(this.getProcessDeploymentStartTopic(), 2, func(client paho.Client, message paho.Message) {
-		if this.debug {
-			log.Println("DEBUG: receive", message.Topic(), string(message.Payload()))
+		err := this.dispatcher.Dispatch(getKey(message.Payload()), func() {
+			handler(command, message)
+		})
+		if err != nil {
+			command.error(ErrorMessage{
+				NetworkId: this.config.NetworkId,
+				Error:     message.Topic() + ": " + err.Error(),
+			})
 		}
-		go this.handleDeploymentStartCommand(message)
 	})
-	this.mqtt.Subscribe(this.getProcessEventUpdateTopic(), 2, func(client paho.Client, message paho.Message) {
-		if this.debug {
-			log.Println("DEBUG: receive", message.Topic(), string(message.Payload()))
+}
+
+// WithSource returns a client marking its state messages with the source (see model.StateSource*)
+func (this *Client) WithSource(source string) *Client {
+	if this == nil {
+		return nil
+	}
+	result := *this
+	result.source = source
+	return &result
+}
+
+func keyByPayload(prefix string) func(payload []byte) string {
+	return func(payload []byte) string {
+		return prefix + ":" + string(payload)
+	}
+}
+
+func keyByJsonField(prefix string, field string) func(payload []byte) string {
+	return func(payload []byte) string {
+		temp := map[string]interface{}{}
+		err := json.Unmarshal(payload, &temp)
+		if err != nil {
+			return ""
 		}
-		go this.handleEventUpdateCommand(message)
-	})
-	this.mqtt.Subscribe(this.getProcessStopTopic(), 2, func(client paho.Client, message paho.Message) {
-		if this.debug {
-			log.Println("DEBUG: receive", message.Topic(), string(message.Payload()))
+		value, ok := temp[field].(string)
+		if !ok || value == "" {
+			return ""
 		}
-		go this.handleProcessStopCommand(message)
-	})
-	this.mqtt.Subscribe(this.getProcessHistoryDeleteTopic(), 2, func(client paho.Client, message paho.Message) {
-		if this.debug {
-			log.Println("DEBUG: receive", message.Topic(), string(message.Payload()))
+		return prefix + ":" + value
+	}
+}
+
+// keyByCamundaDeployment replaces the camunda deployment id of the key with the id of the deployment message
+// so that start, event update and delete commands are ordered with the deployment commands (keyed by the message "id")
+func (this *Client) keyByCamundaDeployment(getKey func(payload []byte) string) func(payload []byte) string {
+	prefix := deploymentTopic + ":"
+	return func(payload []byte) string {
+		key := getKey(payload)
+		if key == "" {
+			return ""
 		}
-		go this.handleProcessHistoryDeleteCommand(message)
-	})
-	this.mqtt.Subscribe(this.getProcessIncidentTopic(), 2, func(client paho.Client, message paho.Message) {
-		if this.debug {
-			log.Println("DEBUG: receive", message.Topic(), string(message.Payload()))
+		return prefix + this.handler.GetDeploymentId(strings.TrimPrefix(key, prefix))
+	}
+}
+
+func unordered(payload []byte) string {
+	return ""
+}
+
+func (this *Client) startDispatcherStateReport(ctx context.Context) {
+	if this.config.CommandQueueReportInterval == "" || this.config.CommandQueueReportInterval == "-" {
+		return
+	}
+	interval, err := time.ParseDuration(this.config.CommandQueueReportInterval)
+	if err != nil {
+		log.Println("WARNING: unable to parse command queue report interval", this.config.CommandQueueReportInterval, err)
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := this.SendCommandQueueState(this.dispatcher.State())
+				if err != nil {
+					log.Println("WARNING: unable to send command queue state", err)
+				}
+			}
 		}
-		go this.handleProcessIncident(message)
-	})
+	}()
+}
+
+func (this *Client) SendCommandQueueState(state DispatcherState) error {
+	return this.sendObj(this.getStateTopic("command-queue"), state)
 }
 
 func (this *Client) getBaseTopic() string {
@@ -168,18 +268,113 @@ func (this *Client) sendObj(topic string, message interface{}) (err error) {
 	if this.debug {
 		log.Println("DEBUG: sendObj", topic, string(msg))
 	}
-	token := this.mqtt.Publish(topic, 2, false, msg)
-	token.Wait()
-	return token.Error()
+	return this.publish(topic, msg)
 }
 
 func (this *Client) sendStr(topic string, message string) error {
 	if this.debug {
 		log.Println("DEBUG: sendObj", topic, message)
 	}
-	token := this.mqtt.Publish(topic, 2, false, message)
-	token.Wait()
-	return token.Error()
+	return this.publish(topic, message)
+}
+
+// publish applies the rate limit of the state topic entity and wraps state payloads in model.StateEnvelope if configured
+// rate limited messages are sent asynchronously; their send errors are logged
+func (this *Client) publish(topic string, payload interface{}) error {
+	entity := this.getStateEntity(topic)
+	publish := func() error {
+		msg := payload
+		if this.config.StateEnvelope && entity != "" {
+			var err error
+			msg, err = this.wrapInEnvelope(payload)
+			if err != nil {
+				return err
+			}
+		}
+		token := this.mqtt.Publish(topic, 2, false, msg)
+		token.Wait()
+		return token.Error()
+	}
+	if limiter, ok := this.limiters[entity]; ok {
+		limiter.Do(getMessageId(payload), topic, func() {
+			err := publish()
+			if err != nil {
+				log.Println("ERROR: unable to send rate limited message", topic, err)
+			}
+		})
+		return nil
+	}
+	return publish()
+}
+
+// getMessageId returns the id of the entity of a state message, used to replace queued rate limited messages
+// deletes contain the id as text, updates as json field "id"; other messages return ""
+func getMessageId(payload interface{}) string {
+	switch v := payload.(type) {
+	case string:
+		return v
+	case []byte:
+		temp := struct {
+			Id string `json:"id"`
+		}{}
+		if json.Unmarshal(v, &temp) == nil {
+			return temp.Id
+		}
+	}
+	return ""
+}
+
+// wrapInEnvelope expects json as []byte or text as string
+func (this *Client) wrapInEnvelope(payload interface{}) ([]byte, error) {
+	envelope := model.StateEnvelope{
+		Seq:           this.seq.Add(1),
+		Time:          time.Now(),
+		Source:        this.source,
+		SchemaVersion: model.StateSchemaVersion,
+		Payload:       payload,
+	}
+	if msg, ok := payload.([]byte); ok {
+		if json.Valid(msg) {
+			envelope.Payload = json.RawMessage(msg)
+		} else {
+			envelope.Payload = string(msg)
+		}
+	}
+	return json.Marshal(envelope)
+}
+
+// getStateEntity returns the entity of a state topic (e.g. "process-instance" for ".../state/process-instance/delete")
+func (this *Client) getStateEntity(topic string) string {
+	prefix := this.getBaseTopic() + "/state/"
+	if !strings.HasPrefix(topic, prefix) {
+		return ""
+	}
+	return strings.SplitN(strings.TrimPrefix(topic, prefix), "/", 2)[0]
+}
+
+// sendDebounced publishes only the latest message of the entity id within state_update_debounce
+func (this *Client) sendDebounced(topic string, id string, message interface{}) error {
+	if this.debouncer == nil {
+		return this.sendObj(topic, message)
+	}
+	this.debouncer.Update(topic+"\n"+id, func() {
+		err := this.sendObj(topic, message)
+		if err != nil {
+			log.Println("ERROR: unable to send debounced update", topic, err)
+		}
+	})
+	return nil
+}
+
+// sendStrAfterDebounced publishes the pending debounced message of the entity id before the message (e.g. a delete)
+func (this *Client) sendStrAfterDebounced(debouncedTopic string, id string, topic string, message string) (err error) {
+	if this.debouncer == nil {
+		return this.sendStr(topic, message)
+	}
+	this.debouncer.Flush(debouncedTopic+"\n"+id, func() {
+		err = this.sendStr(topic, message)
+	})
+	return err
 }
 
 func (this *Client) GetMqttClient() paho.Client {
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+)
+
+func TestStateEnvelope(t *testing.T) {
+	client := &Client{
+		config: configuration.Config{NetworkId: "net", StateEnvelope: true},
+		seq:    &atomic.Int64{},
+		source: model.StateSourceTrigger,
+	}
+	fullSync := client.WithSource(model.StateSourceFullSync)
+
+	if entity := client.getStateEntity("processes/net/state/process-instance/delete"); entity != "process-instance" {
+		t.Error(entity)
+	}
+	if entity := client.getStateEntity("processes/net/cmd/process-instance/delete"); entity != "" {
+		t.Error(entity)
+	}
+
+	check := func(msg []byte, err error, expectedSeq int64, expectedSource string, expectedPayload string) {
+		t.Helper()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		envelope := struct {
+			model.StateEnvelope
+			Payload json.RawMessage `json:"payload"`
+		}{}
+		err = json.Unmarshal(msg, &envelope)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if envelope.Seq != expectedSeq || envelope.Source != expectedSource || envelope.SchemaVersion != model.StateSchemaVersion || envelope.Time.IsZero() {
+			t.Error(string(msg))
+		}
+		if string(envelope.Payload) != expectedPayload {
+			t.Error(string(envelope.Payload))
+		}
+	}
+
+	msg, err := client.wrapInEnvelope([]byte(`{"id":"i1"}`))
+	check(msg, err, 1, model.StateSourceTrigger, `{"id":"i1"}`)
+	msg, err = fullSync.wrapInEnvelope("i1")
+	check(msg, err, 2, model.StateSourceFullSync, `"i1"`)
+	msg, err = client.wrapInEnvelope([]byte("plain error"))
+	check(msg, err, 3, model.StateSourceTrigger, `"plain error"`)
+}
+
+// fakeDryRunHandler returns an empty dry-run result
+type fakeDryRunHandler struct {
+	Handler
+}
+
+func (this *fakeDryRunHandler) DryRunDeployment(payload model.FogDeploymentMessage) model.DeploymentDryRunResult {
+	return model.DeploymentDryRunResult{}
+}
+
+func TestCommandStatesSource(t *testing.T) {
+	mqtt := &fakeMqtt{}
+	client := (&Client{
+		mqtt:    mqtt,
+		config:  configuration.Config{NetworkId: "net", StateEnvelope: true},
+		handler: &fakeDryRunHandler{},
+		seq:     &atomic.Int64{},
+		source:  model.StateSourceTrigger,
+	}).WithSource(model.StateSourceCommand)
+	client.handleDeploymentDryRunCommand(fakeMessage{topic: client.getDeploymentDryRunTopic(), payload: []byte(`{"id":"d1"}`)})
+	published := mqtt.published[client.getStateTopic(deploymentTopic, "dry-run")]
+	if len(published) != 1 {
+		t.Error(mqtt.published)
+		return
+	}
+	envelope := model.StateEnvelope{}
+	err := json.Unmarshal([]byte(published[0]), &envelope)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if envelope.Source != model.StateSourceCommand {
+		t.Error(envelope.Source)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer sends only the latest update of a key within the debounce window
+// the sends of a key are serialized to keep the order of flushed updates and following deletes
+type Debouncer struct {
+	window  time.Duration
+	mux     sync.Mutex
+	pending map[string]func()
+	sending map[string]*keyLock //by key; only held while a send of the key is in progress
+}
+
+type keyLock struct {
+	mux   sync.Mutex
+	users int
+}
+
+func NewDebouncer(window time.Duration) *Debouncer {
+	return &Debouncer{window: window, pending: map[string]func(){}, sending: map[string]*keyLock{}}
+}
+
+// Update replaces the pending update of the key; the first update of a key starts the debounce window
+func (this *Debouncer) Update(key string, send func()) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	_, scheduled := this.pending[key]
+	this.pending[key] = send
+	if !scheduled {
+		time.AfterFunc(this.window, func() {
+			this.Flush(key, nil)
+		})
+	}
+}
+
+// Flush sends the pending update of the key followed by next (e.g. a delete); next may be nil
+// flushes of other keys are not blocked
+func (this *Debouncer) Flush(key string, next func()) {
+	lock := this.lockKey(key)
+	defer this.unlockKey(key, lock)
+	this.mux.Lock()
+	send, ok := this.pending[key]
+	delete(this.pending, key)
+	this.mux.Unlock()
+	if ok {
+		send()
+	}
+	if next != nil {
+		next()
+	}
+}
+
+func (this *Debouncer) lockKey(key string) *keyLock {
+	this.mux.Lock()
+	lock, ok := this.sending[key]
+	if !ok {
+		lock = &keyLock{}
+		this.sending[key] = lock
+	}
+	lock.users++
+	this.mux.Unlock()
+	lock.mux.Lock()
+	return lock
+}
+
+func (this *Debouncer) unlockKey(key string, lock *keyLock) {
+	lock.mux.Unlock()
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	lock.users--
+	if lock.users == 0 {
+		delete(this.sending, key)
+	}
+}
+
+// RateLimiter enforces a minimal interval between sends without blocking the caller
+// sends are queued and executed in order by a background goroutine
+// a queued send is replaced by a later send of the same id and topic, so the queue holds at most one pending state per id
+type RateLimiter struct {
+	interval time.Duration
+	mux      sync.Mutex
+	last     time.Time
+	queue    []*rateLimitedSend
+	latest   map[string]*rateLimitedSend //latest queued send by id
+	running  bool
+}
+
+type rateLimitedSend struct {
+	id    string
+	topic string
+	send  func()
+}
+
+func NewRateLimiter(interval time.Duration) *RateLimiter {
+	return &RateLimiter{interval: interval, latest: map[string]*rateLimitedSend{}}
+}
+
+// Do queues send; sends with an empty id are never replaced
+// the latest queued send of the id is only replaced if it has the same topic, to keep e.g. an update after a delete
+func (this *RateLimiter) Do(id string, topic string, send func()) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if latest, ok := this.latest[id]; ok && id != "" && latest.topic == topic {
+		latest.send = send
+		return
+	}
+	item := &rateLimitedSend{id: id, topic: topic, send: send}
+	this.queue = append(this.queue, item)
+	if id != "" {
+		this.latest[id] = item
+	}
+	if !this.running {
+		this.running = true
+		go this.run()
+	}
+}
+
+func (this *RateLimiter) run() {
+	for {
+		this.mux.Lock()
+		if len(this.queue) == 0 {
+			this.running = false
+			this.mux.Unlock()
+			return
+		}
+		wait := time.Until(this.last.Add(this.interval))
+		this.mux.Unlock()
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+		this.mux.Lock()
+		item := this.queue[0]
+		this.queue[0] = nil
+		this.queue = this.queue[1:]
+		if this.latest[item.id] == item {
+			delete(this.latest, item.id)
+		}
+		send := item.send
+		this.last = time.Now()
+		this.mux.Unlock()
+		send()
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDebouncer(t *testing.T) {
+	debouncer := NewDebouncer(50 * time.Millisecond)
+	mux := sync.Mutex{}
+	sent := []string{}
+	send := func(msg string) func() {
+		return func() {
+			mux.Lock()
+			defer mux.Unlock()
+			sent = append(sent, msg)
+		}
+	}
+
+	debouncer.Update("a", send("a1"))
+	debouncer.Update("a", send("a2"))
+	debouncer.Update("b", send("b1"))
+	debouncer.Update("a", send("a3"))
+	debouncer.Update("c", send("c1"))
+	debouncer.Flush("c", send("c-delete"))
+	debouncer.Flush("d", send("d-delete"))
+
+	time.Sleep(100 * time.Millisecond)
+	debouncer.Update("a", send("a4"))
+	time.Sleep(100 * time.Millisecond)
+
+	mux.Lock()
+	defer mux.Unlock()
+	expected := []string{"c1", "c-delete", "d-delete", "a3", "b1", "a4"}
+	if len(sent) != len(expected) {
+		t.Error(sent)
+		return
+	}
+	//the order of different keys flushed by the same window is not defined
+	if sent[3] == "b1" {
+		sent[3], sent[4] = sent[4], sent[3]
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Error(sent)
+	}
+}
+
+func TestDebouncerFlushPerKey(t *testing.T) {
+	debouncer := NewDebouncer(time.Hour)
+	blocked := make(chan struct{})
+	debouncer.Update("a", func() {
+		<-blocked
+	})
+	go debouncer.Flush("a", nil)
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		debouncer.Update("b", func() {})
+		debouncer.Flush("b", nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("flush of key b blocked by send of key a")
+	}
+	close(blocked)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(20 * time.Millisecond)
+	mux := sync.Mutex{}
+	sent := []string{}
+	send := func(msg string) func() {
+		return func() {
+			mux.Lock()
+			defer mux.Unlock()
+			sent = append(sent, msg)
+		}
+	}
+	start := time.Now()
+	limiter.Do("a", "update", send("a1"))
+	limiter.Do("b", "update", send("b1"))
+	limiter.Do("a", "update", send("a2"))
+	limiter.Do("b", "delete", send("b-delete"))
+	limiter.Do("b", "update", send("b2"))
+	limiter.Do("", "known", send("known1"))
+	limiter.Do("", "known", send("known2"))
+	limiter.Do("a", "update", send("a3"))
+	if duration := time.Since(start); duration > 10*time.Millisecond {
+		t.Error("expected non blocking calls", duration)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	mux.Lock()
+	if len(sent) > 3 {
+		t.Error("expected rate limited sends", sent)
+	}
+	mux.Unlock()
+
+	time.Sleep(200 * time.Millisecond)
+	mux.Lock()
+	defer mux.Unlock()
+	//a1 may already be sent before the following sends of a are queued
+	if len(sent) > 0 && sent[0] == "a1" {
+		sent = sent[1:]
+	}
+	expected := []string{"a3", "b1", "b-delete", "b2", "known1", "known2"}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Error(sent)
+	}
+}
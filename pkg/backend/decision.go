@@ -0,0 +1,62 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const decisionTopic = "decision"
+
+func (this *Client) getDecisionEvaluateTopic() string {
+	return this.getCommandTopic(decisionTopic, "evaluate")
+}
+
+func (this *Client) handleDecisionEvaluateCommand(message paho.Message) {
+	msg := model.DecisionEvaluateMessage{}
+	err := json.Unmarshal(message.Payload(), &msg)
+	if err != nil {
+		this.error(ErrorMessage{
+			NetworkId:           this.config.NetworkId,
+			DeploymentId:        "",
+			CamundaDeploymentId: "",
+			BusinessKey:         "",
+			Error:               err.Error(),
+		})
+		return
+	}
+	result := model.DecisionEvaluateResult{
+		RequestId:   msg.RequestId,
+		DecisionKey: msg.DecisionKey,
+	}
+	result.Result, err = this.handler.EvaluateDecision(msg.DecisionKey, model.MergeTypedVariables(msg.Variables, msg.TypedVariables))
+	if err != nil {
+		result.Error = err.Error()
+	}
+	err = this.SendDecisionEvaluateResult(result)
+	if err != nil {
+		log.Println("ERROR: unable to send decision evaluation result", err)
+	}
+}
+
+func (this *Client) SendDecisionEvaluateResult(result model.DecisionEvaluateResult) error {
+	return this.sendObj(this.getStateTopic(decisionTopic, "evaluate"), result)
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// fakeMqtt records published messages; other paho.Client methods are not implemented
+type fakeMqtt struct {
+	paho.Client
+	mux       sync.Mutex
+	published map[string][]string //by topic
+}
+
+func (this *fakeMqtt) Publish(topic string, qos byte, retained bool, payload interface{}) paho.Token {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.published == nil {
+		this.published = map[string][]string{}
+	}
+	switch v := payload.(type) {
+	case []byte:
+		this.published[topic] = append(this.published[topic], string(v))
+	case string:
+		this.published[topic] = append(this.published[topic], v)
+	}
+	return doneToken{}
+}
+
+type doneToken struct{}
+
+func (this doneToken) Wait() bool                     { return true }
+func (this doneToken) WaitTimeout(time.Duration) bool { return true }
+func (this doneToken) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+func (this doneToken) Error() error { return nil }
+
+type fakeMessage struct {
+	paho.Message
+	topic   string
+	payload []byte
+}
+
+func (this fakeMessage) Topic() string   { return this.topic }
+func (this fakeMessage) Payload() []byte { return this.payload }
+
+// fakeDecisionHandler evaluates only the decision "known"
+type fakeDecisionHandler struct {
+	Handler
+	variables map[string]interface{}
+}
+
+func (this *fakeDecisionHandler) EvaluateDecision(decisionKey string, variables map[string]interface{}) ([]map[string]camundamodel.Variable, error) {
+	this.variables = variables
+	if decisionKey != "known" {
+		return nil, errors.New("unknown decision")
+	}
+	return []map[string]camundamodel.Variable{{"level": {Type: "Integer", Value: 3.0}}}, nil
+}
+
+func TestHandleDecisionEvaluateCommand(t *testing.T) {
+	mqtt := &fakeMqtt{}
+	handler := &fakeDecisionHandler{}
+	client := (&Client{
+		mqtt:    mqtt,
+		config:  configuration.Config{NetworkId: "net"},
+		handler: handler,
+		seq:     &atomic.Int64{},
+	}).WithSource(model.StateSourceCommand)
+
+	evaluate := func(msg model.DecisionEvaluateMessage) (result model.DecisionEvaluateResult) {
+		t.Helper()
+		payload, _ := json.Marshal(msg)
+		client.handleDecisionEvaluateCommand(fakeMessage{topic: client.getDecisionEvaluateTopic(), payload: payload})
+		mqtt.mux.Lock()
+		defer mqtt.mux.Unlock()
+		list := mqtt.published["processes/net/state/decision/evaluate"]
+		if len(list) == 0 {
+			t.Error("missing result")
+			return
+		}
+		err := json.Unmarshal([]byte(list[len(list)-1]), &result)
+		if err != nil {
+			t.Error(err)
+		}
+		return
+	}
+
+	result := evaluate(model.DecisionEvaluateMessage{
+		RequestId:      "r1",
+		DecisionKey:    "known",
+		Variables:      map[string]interface{}{"room": "lab", "temperature": 20},
+		TypedVariables: map[string]camundamodel.Variable{"temperature": {Type: "Double", Value: "21.5"}},
+	})
+	if result.RequestId != "r1" || result.Error != "" || len(result.Result) != 1 {
+		t.Errorf("%#v", result)
+	}
+	if variable, ok := handler.variables["temperature"].(camundamodel.Variable); !ok || variable.Type != "Double" || handler.variables["room"] != "lab" {
+		t.Errorf("%#v", handler.variables)
+	}
+
+	result = evaluate(model.DecisionEvaluateMessage{RequestId: "r2", DecisionKey: "unknown"})
+	if result.RequestId != "r2" || result.DecisionKey != "unknown" || result.Error != "unknown decision" || result.Result != nil {
+		t.Errorf("%#v", result)
+	}
+}
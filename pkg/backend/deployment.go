@@ -18,6 +18,8 @@ package backend
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 
 	eventmodel "github.com/SENERGY-Platform/event-worker/pkg/model"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
@@ -46,13 +48,41 @@ func (this *Client) handleDeploymentCommand(message paho.Message) {
 	}
 	camundaId, err := this.handler.CreateDeployment(deployment)
 	if err != nil {
-		this.error(ErrorMessage{
+		msg := ErrorMessage{
 			NetworkId:           this.config.NetworkId,
 			DeploymentId:        deployment.Id,
 			CamundaDeploymentId: camundaId,
 			BusinessKey:         "",
 			Error:               err.Error(),
+		}
+		var validationErrors model.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			msg.ValidationErrors = validationErrors
+		}
+		this.error(msg)
+	}
+}
+
+func (this *Client) getDeploymentDryRunTopic() string {
+	return this.getCommandTopic(deploymentTopic, "dry-run")
+}
+
+func (this *Client) handleDeploymentDryRunCommand(message paho.Message) {
+	deployment := model.FogDeploymentMessage{}
+	err := json.Unmarshal(message.Payload(), &deployment)
+	if err != nil {
+		this.error(ErrorMessage{
+			NetworkId:           this.config.NetworkId,
+			DeploymentId:        "",
+			CamundaDeploymentId: "",
+			BusinessKey:         "",
+			Error:               err.Error(),
 		})
+		return
+	}
+	err = this.SendDeploymentDryRunResult(this.handler.DryRunDeployment(deployment))
+	if err != nil {
+		log.Println("ERROR: unable to send deployment dry-run result", err)
 	}
 }
 
@@ -107,7 +137,7 @@ func (this *Client) handleDeploymentStartCommand(message paho.Message) {
 			Error:               err.Error(),
 		})
 	}
-	err = this.handler.StartDeployment(msg.DeploymentId, msg.BusinessKey, msg.Parameter)
+	err = this.handler.StartDeployment(msg.DeploymentId, msg.BusinessKey, model.MergeTypedVariables(msg.Parameter, msg.TypedParameter))
 	if err != nil {
 		this.error(ErrorMessage{
 			NetworkId:           this.config.NetworkId,
@@ -152,3 +182,19 @@ func (this *Client) SendDeploymentDelete(id string) error {
 func (this *Client) SendDeploymentMetadata(metadata metadata.Metadata) error {
 	return this.sendObj(this.getStateTopic(deploymentTopic, "metadata"), metadata)
 }
+
+func (this *Client) SendDeploymentDryRunResult(result model.DeploymentDryRunResult) error {
+	return this.sendObj(this.getStateTopic(deploymentTopic, "dry-run"), result)
+}
+
+func (this *Client) SendDeploymentDriftReport(report model.DeploymentDriftReport) error {
+	return this.sendObj(this.getStateTopic(deploymentTopic, "drift"), report)
+}
+
+func (this *Client) SendScheduleExecution(execution model.ScheduleExecution) error {
+	return this.sendObj(this.getStateTopic(deploymentTopic, "schedule"), execution)
+}
+
+func (this *Client) SendBusinessKeyDecision(decision model.BusinessKeyDecision) error {
+	return this.sendObj(this.getStateTopic(deploymentTopic, "business-key"), decision)
+}
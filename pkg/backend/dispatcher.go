@@ -0,0 +1,155 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+const DefaultDispatcherQueueLimit = 1000
+
+var ErrDispatcherQueueFull = errors.New("command queue full")
+
+// Dispatcher executes tasks with a fixed number of workers
+// tasks with the same key are executed one after another in the order they were dispatched
+// tasks with an empty key are not ordered
+// at most limit tasks are pending; further tasks are rejected
+type Dispatcher struct {
+	mux     sync.Mutex
+	cond    *sync.Cond
+	queues  map[string][]func()
+	ready   []string        //keys with pending tasks and no running task
+	active  map[string]bool //keys in ready or with a running task
+	pending int
+	running int
+	workers int
+	limit   int
+	counter int64
+	done    bool
+}
+
+type DispatcherState struct {
+	Pending int `json:"pending"`
+	Running int `json:"running"`
+	Workers int `json:"workers"`
+	Limit   int `json:"limit"`
+}
+
+func NewDispatcher(ctx context.Context, workers int, limit int) *Dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	if limit < 1 {
+		limit = DefaultDispatcherQueueLimit
+	}
+	d := &Dispatcher{
+		queues:  map[string][]func(){},
+		active:  map[string]bool{},
+		workers: workers,
+		limit:   limit,
+	}
+	d.cond = sync.NewCond(&d.mux)
+	for i := 0; i < workers; i++ {
+		go d.work()
+	}
+	go func() {
+		<-ctx.Done()
+		d.mux.Lock()
+		defer d.mux.Unlock()
+		d.done = true
+		d.cond.Broadcast()
+	}()
+	return d
+}
+
+// Dispatch queues the task; does not block
+// returns ErrDispatcherQueueFull if the limit of pending tasks is reached
+func (this *Dispatcher) Dispatch(key string, task func()) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.pending >= this.limit {
+		return ErrDispatcherQueueFull
+	}
+	if key == "" {
+		this.counter++
+		key = "unordered:" + strconv.FormatInt(this.counter, 10)
+	}
+	this.queues[key] = append(this.queues[key], task)
+	this.pending++
+	if !this.active[key] {
+		this.active[key] = true
+		this.ready = append(this.ready, key)
+		this.cond.Signal()
+	}
+	return nil
+}
+
+func (this *Dispatcher) State() DispatcherState {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return DispatcherState{
+		Pending: this.pending,
+		Running: this.running,
+		Workers: this.workers,
+		Limit:   this.limit,
+	}
+}
+
+func (this *Dispatcher) work() {
+	for {
+		key, task, ok := this.next()
+		if !ok {
+			return
+		}
+		task()
+		this.finish(key)
+	}
+}
+
+func (this *Dispatcher) next() (key string, task func(), ok bool) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	for len(this.ready) == 0 && !this.done {
+		this.cond.Wait()
+	}
+	if this.done {
+		return "", nil, false
+	}
+	key = this.ready[0]
+	this.ready = this.ready[1:]
+	task = this.queues[key][0]
+	this.queues[key] = this.queues[key][1:]
+	this.pending--
+	this.running++
+	return key, task, true
+}
+
+func (this *Dispatcher) finish(key string) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.running--
+	if len(this.queues[key]) > 0 {
+		this.ready = append(this.ready, key)
+		this.cond.Signal()
+	} else {
+		delete(this.queues, key)
+		delete(this.active, key)
+	}
+}
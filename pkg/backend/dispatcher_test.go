@@ -0,0 +1,177 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDispatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const workers = 3
+	dispatcher := NewDispatcher(ctx, workers, 0)
+
+	mux := sync.Mutex{}
+	order := map[string][]int{}
+	var current, max int64
+	wg := sync.WaitGroup{}
+
+	keys := []string{"a", "b", "c", "d", "e"}
+	for i := 0; i < 20; i++ {
+		for _, key := range keys {
+			wg.Add(1)
+			dispatcher.Dispatch(key, func() {
+				defer wg.Done()
+				running := atomic.AddInt64(&current, 1)
+				defer atomic.AddInt64(&current, -1)
+				for {
+					old := atomic.LoadInt64(&max)
+					if running <= old || atomic.CompareAndSwapInt64(&max, old, running) {
+						break
+					}
+				}
+				mux.Lock()
+				order[key] = append(order[key], i)
+				mux.Unlock()
+				time.Sleep(time.Millisecond)
+			})
+		}
+	}
+	if state := dispatcher.State(); state.Pending+state.Running == 0 || state.Workers != workers {
+		t.Errorf("%#v", state)
+	}
+	wg.Wait()
+
+	if max > workers {
+		t.Error("more tasks running than workers:", max)
+	}
+	expected := []int{}
+	for i := 0; i < 20; i++ {
+		expected = append(expected, i)
+	}
+	for _, key := range keys {
+		if !reflect.DeepEqual(order[key], expected) {
+			t.Error(key, order[key])
+		}
+	}
+	if state := dispatcher.State(); state.Pending != 0 || state.Running != 0 {
+		t.Errorf("%#v", state)
+	}
+}
+
+func TestDispatcherUnordered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dispatcher := NewDispatcher(ctx, 2, 0)
+	block := make(chan struct{})
+	done := make(chan struct{})
+	dispatcher.Dispatch("", func() {
+		<-block
+	})
+	dispatcher.Dispatch("", func() {
+		close(done)
+	})
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("unordered tasks should not wait for each other")
+	}
+	close(block)
+}
+
+func TestKeyByJsonField(t *testing.T) {
+	getKey := keyByJsonField(deploymentTopic, "deployment_id")
+	if key := getKey([]byte(`{"deployment_id": "d1"}`)); key != "deployment:d1" {
+		t.Error(key)
+	}
+	if key := getKey([]byte(`{"id": "d1"}`)); key != "" {
+		t.Error(key)
+	}
+	if key := getKey([]byte(`invalid`)); key != "" {
+		t.Error(key)
+	}
+}
+
+func TestDispatcherLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dispatcher := NewDispatcher(ctx, 1, 2)
+	block := make(chan struct{})
+	defer close(block)
+	running := make(chan struct{})
+	err := dispatcher.Dispatch("a", func() {
+		close(running)
+		<-block
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	<-running
+	for i := 0; i < 2; i++ {
+		if err = dispatcher.Dispatch("a", func() {}); err != nil {
+			t.Error(err)
+		}
+	}
+	if err = dispatcher.Dispatch("b", func() {}); err != ErrDispatcherQueueFull {
+		t.Error(err)
+	}
+	if state := dispatcher.State(); state.Pending != 2 || state.Limit != 2 {
+		t.Errorf("%#v", state)
+	}
+}
+
+// fakeDeploymentIdHandler knows the camunda deployment c1 created from the deployment d1
+type fakeDeploymentIdHandler struct {
+	Handler
+}
+
+func (this fakeDeploymentIdHandler) GetDeploymentId(camundaDeploymentId string) string {
+	if camundaDeploymentId == "c1" {
+		return "d1"
+	}
+	return camundaDeploymentId
+}
+
+func TestKeyByCamundaDeployment(t *testing.T) {
+	client := &Client{handler: fakeDeploymentIdHandler{}}
+	createKey := keyByJsonField(deploymentTopic, "id")([]byte(`{"id": "d1"}`))
+	for _, key := range []string{
+		client.keyByCamundaDeployment(keyByJsonField(deploymentTopic, "deployment_id"))([]byte(`{"deployment_id": "c1"}`)),
+		client.keyByCamundaDeployment(keyByJsonField(deploymentTopic, "camunda_deployment_id"))([]byte(`{"camunda_deployment_id": "c1"}`)),
+		client.keyByCamundaDeployment(keyByPayload(deploymentTopic))([]byte(`c1`)),
+	} {
+		if key != createKey {
+			t.Error(key, createKey)
+		}
+	}
+	if key := client.keyByCamundaDeployment(keyByPayload(deploymentTopic))([]byte(`c2`)); key != "deployment:c2" {
+		t.Error(key)
+	}
+	if key := client.keyByCamundaDeployment(keyByJsonField(deploymentTopic, "deployment_id"))([]byte(`invalid`)); key != "" {
+		t.Error(key)
+	}
+}
@@ -18,6 +18,8 @@ package backend
 
 import (
 	"log"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
 )
 
 type ErrorMessage struct {
@@ -26,6 +28,8 @@ type ErrorMessage struct {
 	CamundaDeploymentId string `json:"camunda_deployment_id"`
 	BusinessKey         string `json:"business_key"`
 	Error               string `json:"error"`
+
+	ValidationErrors model.ValidationErrors `json:"validation_errors,omitempty"`
 }
 
 func (this *Client) error(err ErrorMessage) {
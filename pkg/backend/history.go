@@ -41,11 +41,11 @@ func (this *Client) handleProcessHistoryDeleteCommand(message paho.Message) {
 }
 
 func (this *Client) SendProcessHistoryUpdate(instance model.HistoricProcessInstance) error {
-	return this.sendObj(this.getStateTopic(processInstanceHistoryTopic), instance)
+	return this.sendDebounced(this.getStateTopic(processInstanceHistoryTopic), instance.Id, instance)
 }
 
 func (this *Client) SendProcessHistoryDelete(id string) error {
-	return this.sendStr(this.getStateTopic(processInstanceHistoryTopic, "delete"), id)
+	return this.sendStrAfterDebounced(this.getStateTopic(processInstanceHistoryTopic), id, this.getStateTopic(processInstanceHistoryTopic, "delete"), id)
 }
 
 func (this *Client) SendProcessHistoryKnownIds(ids []string) error {
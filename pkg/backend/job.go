@@ -0,0 +1,35 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	model "github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+const jobTopic = "job"
+
+func (this *Client) SendJobUpdate(job model.Job) error {
+	return this.sendObj(this.getStateTopic(jobTopic), job)
+}
+
+func (this *Client) SendJobDelete(id string) error {
+	return this.sendStr(this.getStateTopic(jobTopic, "delete"), id)
+}
+
+func (this *Client) SendJobKnownIds(ids []string) error {
+	return this.sendObj(this.getStateTopic(jobTopic, "known"), ids)
+}
@@ -24,11 +24,11 @@ import (
 const processInstanceTopic = "process-instance"
 
 func (this *Client) SendProcessInstanceUpdate(instance model.ProcessInstance) error {
-	return this.sendObj(this.getStateTopic(processInstanceTopic), instance)
+	return this.sendDebounced(this.getStateTopic(processInstanceTopic), instance.Id, instance)
 }
 
 func (this *Client) SendProcessInstanceDelete(id string) error {
-	return this.sendStr(this.getStateTopic(processInstanceTopic, "delete"), id)
+	return this.sendStrAfterDebounced(this.getStateTopic(processInstanceTopic), id, this.getStateTopic(processInstanceTopic, "delete"), id)
 }
 
 func (this *Client) getProcessStopTopic() string {
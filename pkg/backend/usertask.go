@@ -0,0 +1,60 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const userTaskTopic = "user-task"
+
+func (this *Client) SendUserTaskUpdate(task camundamodel.Task) error {
+	return this.sendObj(this.getStateTopic(userTaskTopic), task)
+}
+
+func (this *Client) SendUserTaskDelete(id string) error {
+	return this.sendStr(this.getStateTopic(userTaskTopic, "delete"), id)
+}
+
+func (this *Client) SendUserTaskKnownIds(ids []string) error {
+	return this.sendObj(this.getStateTopic(userTaskTopic, "known"), ids)
+}
+
+func (this *Client) getUserTaskCompleteTopic() string {
+	return this.getCommandTopic(userTaskTopic, "complete")
+}
+
+func (this *Client) handleUserTaskCompleteCommand(message paho.Message) {
+	msg := model.UserTaskCompleteMessage{}
+	err := json.Unmarshal(message.Payload(), &msg)
+	if err == nil {
+		err = this.handler.CompleteUserTask(msg.TaskId, model.MergeTypedVariables(msg.Variables, msg.TypedVariables))
+	}
+	if err != nil {
+		this.error(ErrorMessage{
+			NetworkId:           this.config.NetworkId,
+			DeploymentId:        "",
+			CamundaDeploymentId: "",
+			BusinessKey:         "",
+			Error:               err.Error(),
+		})
+	}
+}
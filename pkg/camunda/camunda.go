@@ -85,13 +85,56 @@ func createStartMessage(parameter map[string]interface{}, businessKey string) ma
 	if len(parameter) == 0 {
 		return map[string]interface{}{"businessKey": businessKey}
 	}
+	return map[string]interface{}{"variables": createVariables(parameter), "businessKey": businessKey}
+}
+
+func createVariables(parameter map[string]interface{}) map[string]interface{} {
 	variables := map[string]interface{}{}
 	for key, val := range parameter {
+		if variable, ok := val.(model.Variable); ok {
+			variables[key] = variable
+			continue
+		}
 		variables[key] = map[string]interface{}{
 			"value": val,
 		}
 	}
-	return map[string]interface{}{"variables": variables, "businessKey": businessKey}
+	return variables
+}
+
+// EvaluateDecision evaluates the latest version of the decision definition with the given key
+// returns one map of output variables per matched rule
+func (this *Camunda) EvaluateDecision(decisionKey string, userId string, variables map[string]interface{}) (result []map[string]model.Variable, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	b := new(bytes.Buffer)
+	err = json.NewEncoder(b).Encode(map[string]interface{}{"variables": createVariables(variables)})
+	if err != nil {
+		return result, err
+	}
+	if this.config.Debug == true {
+		log.Println("DEBUG: evaluate decision at camunda:", decisionKey)
+	}
+	req, err := http.NewRequest("POST", shard+"/engine-rest/decision-definition/key/"+url.PathEscape(decisionKey)+"/tenant-id/"+url.PathEscape(userId)+"/evaluate", b)
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		temp, _ := io.ReadAll(resp.Body)
+		err = errors.New(resp.Status + " " + string(temp))
+		return result, err
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
 }
 
 func (this *Camunda) GetProcessParameters(processDefinitionId string, userId string) (result map[string]model.Variable, err error) {
@@ -326,6 +369,147 @@ func (this *Camunda) GetProcessInstanceHistoryList(userId string) (result model.
 	return
 }
 
+const ActivityInstancePageSize = 1000
+
+// GetFilteredActivityInstanceHistoryList loads the historic activity instances matching the query (e.g. processDefinitionId, activityType)
+// in pages of ActivityInstancePageSize
+func (this *Camunda) GetFilteredActivityInstanceHistoryList(userId string, query url.Values) (result model.HistoricActivityInstances, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/history/activity-instance"
+	query.Del("tenantIdIn")
+	query.Set("sortBy", "activityInstanceId") //stable order for paging
+	query.Set("sortOrder", "asc")
+	query.Set("maxResults", strconv.Itoa(ActivityInstancePageSize))
+	for firstResult := 0; ; firstResult = firstResult + ActivityInstancePageSize {
+		query.Set("firstResult", strconv.Itoa(firstResult))
+		page := model.HistoricActivityInstances{}
+		err = request.Get(shard+"/engine-rest/history/activity-instance?tenantIdIn="+url.QueryEscape(userId)+"&"+query.Encode(), &page)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page...)
+		if len(page) < ActivityInstancePageSize {
+			return result, nil
+		}
+	}
+}
+
+func (this *Camunda) GetHistoricVariableInstance(id string, userId string) (result model.HistoricVariableInstance, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/history/variable-instance/{id}"
+	err = request.Get(shard+"/engine-rest/history/variable-instance/"+url.PathEscape(id)+"?deserializeValues=false", &result)
+	return
+}
+
+func (this *Camunda) GetExternalTaskList(userId string) (result model.ExternalTasks, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/external-task"
+	err = request.Get(shard+"/engine-rest/external-task?tenantIdIn="+url.QueryEscape(userId), &result)
+	return
+}
+
+func (this *Camunda) GetExternalTaskErrorDetails(id string, userId string) (result string, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(shard + "/engine-rest/external-task/" + url.PathEscape(id) + "/errorDetails")
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	temp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return result, errors.New(resp.Status + " " + string(temp))
+	}
+	return string(temp), nil
+}
+
+// GetJobList returns timer and async continuation jobs
+func (this *Camunda) GetJobList(userId string) (result model.Jobs, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	for _, jobType := range []struct{ query, name string }{{query: "timers", name: "timer"}, {query: "messages", name: "message"}} {
+		jobs := model.Jobs{}
+		//"/engine-rest/job"
+		err = request.Get(shard+"/engine-rest/job?tenantIdIn="+url.QueryEscape(userId)+"&"+jobType.query+"=true", &jobs)
+		if err != nil {
+			return result, err
+		}
+		for _, job := range jobs {
+			job.Type = jobType.name
+			result = append(result, job)
+		}
+	}
+	return result, nil
+}
+
+func (this *Camunda) GetTaskList(userId string) (result model.Tasks, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/task"
+	err = request.Get(shard+"/engine-rest/task?tenantIdIn="+url.QueryEscape(userId), &result)
+	return
+}
+
+func (this *Camunda) GetTaskFormVariables(id string, userId string) (result map[string]model.Variable, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/task/{id}/form-variables"
+	err = request.Get(shard+"/engine-rest/task/"+url.PathEscape(id)+"/form-variables?deserializeValues=false", &result)
+	return
+}
+
+func (this *Camunda) CompleteTask(id string, userId string, variables map[string]interface{}) error {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return err
+	}
+	b := new(bytes.Buffer)
+	err = json.NewEncoder(b).Encode(map[string]interface{}{"variables": createVariables(variables)})
+	if err != nil {
+		return err
+	}
+	if this.config.Debug == true {
+		log.Println("DEBUG: complete task at camunda:", id)
+	}
+	req, err := http.NewRequest("POST", shard+"/engine-rest/task/"+url.PathEscape(id)+"/complete", b)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		temp, _ := io.ReadAll(resp.Body)
+		return errors.New(resp.Status + " " + string(temp))
+	}
+	return nil
+}
+
 func (this *Camunda) GetFilteredProcessInstanceHistoryList(userId string, query url.Values) (result model.HistoricProcessInstances, err error) {
 	shard, err := this.shards.EnsureShardForUser(userId)
 	if err != nil {
@@ -373,6 +557,26 @@ func (this *Camunda) GetProcessInstanceList(userId string) (result model.Process
 	return
 }
 
+func (this *Camunda) GetProcessInstancesByDefinition(processDefinitionId string, userId string) (result model.ProcessInstances, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/process-instance"
+	err = request.Get(shard+"/engine-rest/process-instance?tenantIdIn="+url.QueryEscape(userId)+"&processDefinitionId="+url.QueryEscape(processDefinitionId), &result)
+	return
+}
+
+func (this *Camunda) GetProcessInstancesByBusinessKey(processDefinitionId string, businessKey string, userId string) (result model.ProcessInstances, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/process-instance"
+	err = request.Get(shard+"/engine-rest/process-instance?tenantIdIn="+url.QueryEscape(userId)+"&processDefinitionId="+url.QueryEscape(processDefinitionId)+"&businessKey="+url.QueryEscape(businessKey), &result)
+	return
+}
+
 func (this *Camunda) GetProcessDefinition(id string, userId string) (result model.ProcessDefinition, err error) {
 	shard, err := this.shards.EnsureShardForUser(userId)
 	if err != nil {
@@ -386,6 +590,17 @@ func (this *Camunda) GetProcessDefinition(id string, userId string) (result mode
 	return
 }
 
+func (this *Camunda) GetProcessDefinitionXml(id string, userId string) (result string, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	//"/engine-rest/process-definition/" + processDefinitionId + "/xml"
+	temp := model.ProcessDefinitionXml{}
+	err = request.Get(shard+"/engine-rest/process-definition/"+url.QueryEscape(id)+"/xml", &temp)
+	return temp.Bpmn20Xml, err
+}
+
 func (this *Camunda) GetProcessDefinitionList(userId string) (result model.ProcessDefinitions, err error) {
 	shard, err := this.shards.EnsureShardForUser(userId)
 	if err != nil {
@@ -396,6 +611,21 @@ func (this *Camunda) GetProcessDefinitionList(userId string) (result model.Proce
 	return
 }
 
+// EscapeLike escapes the sql like wildcards of the value; camunda uses '\' as like escape character
+func EscapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// GetProcessDefinitionsByKeyLike returns the process definitions with a key matching the sql like pattern (e.g. EscapeLike("deplid_")+"%")
+func (this *Camunda) GetProcessDefinitionsByKeyLike(keyLike string, userId string) (result model.ProcessDefinitions, err error) {
+	shard, err := this.shards.EnsureShardForUser(userId)
+	if err != nil {
+		return result, err
+	}
+	err = request.Get(shard+"/engine-rest/process-definition?keyLike="+url.QueryEscape(keyLike), &result)
+	return
+}
+
 func (this *Camunda) GetProcessDefinitionDiagram(id string, userId string) (resp *http.Response, err error) {
 	shard, err := this.shards.EnsureShardForUser(userId)
 	if err != nil {
@@ -452,7 +682,7 @@ func (this *Camunda) GetDeploymentCountByShard(deploymentId string, shard string
 	return
 }
 
-func buildPayLoad(name string, xml string, svg string, boundary string, owner string, deploymentSource string) string {
+func buildPayLoad(name string, xml string, svg string, resources []model.DeploymentResource, boundary string, owner string, deploymentSource string) string {
 	segments := []string{}
 	if deploymentSource == "" {
 		deploymentSource = "sepl"
@@ -460,6 +690,9 @@ func buildPayLoad(name string, xml string, svg string, boundary string, owner st
 
 	segments = append(segments, "Content-Disposition: form-data; name=\"data\"; "+"filename=\""+name+".bpmn\"\r\nContent-Type: text/xml\r\n\r\n"+xml+"\r\n")
 	segments = append(segments, "Content-Disposition: form-data; name=\"diagram\"; "+"filename=\""+name+".svg\"\r\nContent-Type: image/svg+xml\r\n\r\n"+svg+"\r\n")
+	for _, resource := range resources {
+		segments = append(segments, "Content-Disposition: form-data; name=\""+resource.Name+"\"; "+"filename=\""+resource.Name+"\"\r\nContent-Type: "+getResourceContentType(resource.Name)+"\r\n\r\n"+resource.Content+"\r\n")
+	}
 	segments = append(segments, "Content-Disposition: form-data; name=\"deployment-name\"\r\n\r\n"+name+"\r\n")
 	segments = append(segments, "Content-Disposition: form-data; name=\"deployment-source\"\r\n\r\n"+deploymentSource+"\r\n")
 	segments = append(segments, "Content-Disposition: form-data; name=\"tenant-id\"\r\n\r\n"+owner+"\r\n")
@@ -467,9 +700,23 @@ func buildPayLoad(name string, xml string, svg string, boundary string, owner st
 	return "--" + boundary + "\r\n" + strings.Join(segments, "--"+boundary+"\r\n") + "--" + boundary + "--\r\n"
 }
 
+func getResourceContentType(name string) string {
+	switch {
+	case strings.HasSuffix(name, ".bpmn"), strings.HasSuffix(name, ".dmn"), strings.HasSuffix(name, ".cmmn"):
+		return "text/xml"
+	case strings.HasSuffix(name, ".form"), strings.HasSuffix(name, ".json"):
+		return "application/json"
+	case strings.HasSuffix(name, ".html"):
+		return "text/html"
+	default:
+		return "application/octet-stream"
+	}
+}
+
 // returns original deploymentId (not vid)
-func (this *Camunda) DeployProcess(name string, xml string, svg string, owner string, source string) (deploymentId string, err error) {
-	responseWrapper, err := this.deployProcess(name, xml, svg, owner, source)
+// resources are deployed alongside the bpmn and svg (e.g. .dmn decision tables or .form files)
+func (this *Camunda) DeployProcess(name string, xml string, svg string, resources []model.DeploymentResource, owner string, source string) (deploymentId string, err error) {
+	responseWrapper, err := this.deployProcess(name, xml, svg, resources, owner, source)
 	if err != nil {
 		log.Println("ERROR: unable to decode process engine deployment response", err)
 		return deploymentId, err
@@ -493,14 +740,14 @@ func (this *Camunda) DeployProcess(name string, xml string, svg string, owner st
 	return deploymentId, nil
 }
 
-func (this *Camunda) deployProcess(name string, xml string, svg string, owner string, source string) (result map[string]interface{}, err error) {
+func (this *Camunda) deployProcess(name string, xml string, svg string, resources []model.DeploymentResource, owner string, source string) (result map[string]interface{}, err error) {
 	shard, err := this.shards.EnsureShardForUser(owner)
 	if err != nil {
 		return result, err
 	}
 	result = map[string]interface{}{}
 	boundary := "---------------------------" + time.Now().String()
-	b := strings.NewReader(buildPayLoad(name, xml, svg, boundary, owner, source))
+	b := strings.NewReader(buildPayLoad(name, xml, svg, resources, boundary, owner, source))
 	if this.config.Debug == true {
 		log.Println("DEBUG: deploy process to camunda:", name)
 	}
@@ -48,6 +48,10 @@ type Config struct {
 	NetworkId             string `json:"network_id"`
 	FullUpdateInterval    string `json:"full_update_interval"`
 
+	CommandWorkerCount         int64  `json:"command_worker_count"`
+	CommandQueueLimit          int64  `json:"command_queue_limit"`
+	CommandQueueReportInterval string `json:"command_queue_report_interval"`
+
 	HistoryCleanupInterval      string `json:"history_cleanup_interval"`
 	HistoryCleanupMaxAge        string `json:"history_cleanup_max_age"`
 	HistoryCleanupBatchSize     int    `json:"history_cleanup_batch_size"`
@@ -57,6 +61,84 @@ type Config struct {
 	NotificationUrl             string `json:"notification_url"`
 
 	TaskTopicReplace map[string]string `json:"task_topic_replace"`
+
+	KnownTaskTopics                  []string `json:"known_task_topics"`
+	InvalidDeploymentFallbackToBlank bool     `json:"invalid_deployment_fallback_to_blank"`
+
+	DeploymentRewriteRules []RewriteRule `json:"deployment_rewrite_rules"`
+
+	DeploymentDriftRepair string `json:"deployment_drift_repair"`
+
+	ScheduleCheckInterval string `json:"schedule_check_interval"`
+	ScheduleLocation      string `json:"schedule_location"`
+
+	KeepAliveMinStartInterval string `json:"keep_alive_min_start_interval"`
+
+	WatchdogCheckInterval string `json:"watchdog_check_interval"`
+
+	SyncActivityInstances            bool     `json:"sync_activity_instances"`
+	ActivityInstanceDeploymentFilter []string `json:"activity_instance_deployment_filter"`
+	ActivityInstanceTypeFilter       []string `json:"activity_instance_type_filter"`
+
+	SyncProcessVariables   bool            `json:"sync_process_variables"`
+	VariableRedactionRules []RedactionRule `json:"variable_redaction_rules"`
+
+	SyncExternalTasks           bool   `json:"sync_external_tasks"`
+	ExternalTaskSummaryInterval string `json:"external_task_summary_interval"`
+
+	SyncJobs bool `json:"sync_jobs"`
+
+	SyncUserTasks bool `json:"sync_user_tasks"`
+
+	KpiWindow string `json:"kpi_window"`
+
+	StateUpdateDebounce string            `json:"state_update_debounce"`
+	StateRateLimits     map[string]string `json:"state_rate_limits"`
+
+	StateEnvelope bool `json:"state_envelope"`
+
+	SyncInclude []SyncFilter `json:"sync_include"`
+	SyncExclude []SyncFilter `json:"sync_exclude"`
+}
+
+const (
+	DriftRepairNone     = ""         //only report drift
+	DriftRepairRemove   = "remove"   //remove metadata of deployments missing in camunda
+	DriftRepairRedeploy = "redeploy" //redeploy stored deployments missing in camunda or with changed xml
+)
+
+const (
+	RewriteActionSetAttribute  = "set_attribute"
+	RewriteActionReplaceText   = "replace_text"
+	RewriteActionRemoveElement = "remove_element"
+	RewriteActionPlaceholder   = "placeholder"
+)
+
+// RewriteRule describes a modification of the deployed bpmn xml
+// Path selects the affected elements (etree path syntax, e.g. //bpmn:serviceTask[@camunda:topic='optimistic'])
+// the used value is read from the environment variable Env, if set, with Value as fallback
+type RewriteRule struct {
+	Path      string `json:"path"`
+	Action    string `json:"action"`
+	Attribute string `json:"attribute,omitempty"` //set_attribute: attribute to set; placeholder: optional restriction to this attribute
+	Search    string `json:"search,omitempty"`    //replace_text: optional text to replace, complete text if empty; placeholder: the placeholder
+	Value     string `json:"value,omitempty"`
+	Env       string `json:"env,omitempty"`
+}
+
+// RedactionRule masks values of synced process variables
+type RedactionRule struct {
+	Variable    string `json:"variable"`              //path.Match pattern of the variable name, e.g. "*password*"
+	Pattern     string `json:"pattern,omitempty"`     //regular expression of the masked parts of string values; the complete value if empty
+	Replacement string `json:"replacement,omitempty"` //"***" if empty
+}
+
+// SyncFilter selects deployments by all set fields; an empty filter matches every deployment
+type SyncFilter struct {
+	Name   string            `json:"name,omitempty"`   //path.Match pattern of the deployment name
+	Source string            `json:"source,omitempty"` //e.g. "senergy" for deployments of the sync client
+	Tenant string            `json:"tenant,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"` //labels of the deployment metadata
 }
 
 // loads config from json in location and used environment variables (e.g ZookeeperUrl --> ZOOKEEPER_URL)
@@ -118,7 +200,12 @@ func handleEnvironmentVars(config *Config) {
 				f, _ := strconv.ParseFloat(envValue, 64)
 				configValue.FieldByName(fieldName).SetFloat(f)
 			}
-			if configValue.FieldByName(fieldName).Kind() == reflect.Slice {
+			if configValue.FieldByName(fieldName).Kind() == reflect.Slice && configValue.FieldByName(fieldName).Type().Elem().Kind() != reflect.String {
+				err := json.Unmarshal([]byte(envValue), configValue.FieldByName(fieldName).Addr().Interface())
+				if err != nil {
+					fmt.Println("WARNING: unable to parse environment variable as json: ", envName, err)
+				}
+			} else if configValue.FieldByName(fieldName).Kind() == reflect.Slice {
 				val := []string{}
 				for _, element := range strings.Split(envValue, ",") {
 					val = append(val, strings.TrimSpace(element))
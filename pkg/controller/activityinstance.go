@@ -0,0 +1,180 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"log"
+	"net/url"
+	"slices"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// {"id_":"StartEvent_1:6b84bb05-750c-11eb-b54c-0242ac110006","parent_act_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_def_key_":"ExampleId","proc_def_id_":"ExampleId:1:686e7a53-750c-11eb-b54c-0242ac110006","root_proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","execution_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","act_id_":"StartEvent_1","task_id_":null,"call_proc_inst_id_":null,"call_case_inst_id_":null,"act_name_":null,"act_type_":"startEvent","assignee_":null,"start_time_":"2021-02-22T12:49:36.886","end_time_":"2021-02-22T12:49:36.887","removal_time_":null,"duration_":1,"act_inst_state_":4,"sequence_counter_":1,"tenant_id_":"user"}
+type ActivityInstanceInPg struct {
+	Id                       string  `json:"id_"`
+	ParentActivityInstanceId string  `json:"parent_act_inst_id_"`
+	ProcessDefinitionKey     string  `json:"proc_def_key_"`
+	ProcessDefinitionId      string  `json:"proc_def_id_"`
+	ProcessInstanceId        string  `json:"proc_inst_id_"`
+	ExecutionId              string  `json:"execution_id_"`
+	ActivityId               string  `json:"act_id_"`
+	TaskId                   string  `json:"task_id_"`
+	CalledProcessInstanceId  string  `json:"call_proc_inst_id_"`
+	ActivityName             string  `json:"act_name_"`
+	ActivityType             string  `json:"act_type_"`
+	Assignee                 string  `json:"assignee_"`
+	StartTime                string  `json:"start_time_"`
+	EndTime                  string  `json:"end_time_"`
+	DurationInMillis         float64 `json:"duration_"`
+	State                    int     `json:"act_inst_state_"` //1: scope complete, 2: canceled
+	TenantId                 string  `json:"tenant_id_"`
+}
+
+func (this *Controller) NotifyActivityInstanceUpdate(extra string) {
+	element := ActivityInstanceInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal activity instance in NotifyActivityInstanceUpdate(): ", err)
+		return
+	}
+	if !this.activityInstanceIsSynced(element.ProcessDefinitionKey, element.ActivityType) || !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	err = this.backend.SendActivityInstanceUpdate(camundamodel.HistoricActivityInstance{
+		Id:                       element.Id,
+		ParentActivityInstanceId: element.ParentActivityInstanceId,
+		ActivityId:               element.ActivityId,
+		ActivityName:             element.ActivityName,
+		ActivityType:             element.ActivityType,
+		ProcessDefinitionKey:     element.ProcessDefinitionKey,
+		ProcessDefinitionId:      element.ProcessDefinitionId,
+		ProcessInstanceId:        element.ProcessInstanceId,
+		ExecutionId:              element.ExecutionId,
+		TaskId:                   element.TaskId,
+		CalledProcessInstanceId:  element.CalledProcessInstanceId,
+		Assignee:                 element.Assignee,
+		StartTime:                element.StartTime,
+		EndTime:                  element.EndTime,
+		DurationInMillis:         element.DurationInMillis,
+		Canceled:                 element.State == 2,
+		CompleteScope:            element.State == 1,
+		TenantId:                 element.TenantId,
+	})
+	if err != nil {
+		log.Println("ERROR: unable to send activity instance update in NotifyActivityInstanceUpdate(): ", err)
+		return
+	}
+}
+
+func (this *Controller) NotifyActivityInstanceDelete(extra string) {
+	element := ActivityInstanceInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal activity instance in NotifyActivityInstanceDelete(): ", err)
+		return
+	}
+	if !this.activityInstanceIsSynced(element.ProcessDefinitionKey, element.ActivityType) || !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	err = this.backend.SendActivityInstanceDelete(element.Id)
+	if err != nil {
+		log.Println("ERROR: unable to send activity instance delete in NotifyActivityInstanceDelete(): ", err)
+		return
+	}
+}
+
+func (this *Controller) SendCurrentActivityInstances() error {
+	if !this.config.SyncActivityInstances {
+		return nil
+	}
+	instances, err := this.getCurrentActivityInstances()
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, instance := range instances {
+		if !this.activityInstanceIsSynced(instance.ProcessDefinitionKey, instance.ActivityType) || !this.isSyncedDefinition(instance.ProcessDefinitionId) {
+			continue
+		}
+		ids = append(ids, instance.Id)
+		err = this.fullSync.SendActivityInstanceUpdate(instance)
+		if err != nil {
+			return err
+		}
+	}
+	return this.fullSync.SendActivityInstanceKnownIds(ids)
+}
+
+// getCurrentActivityInstances loads the historic activity instances with the deployment and type filters applied by camunda
+func (this *Controller) getCurrentActivityInstances() (result []camundamodel.HistoricActivityInstance, err error) {
+	definitionIds := []string{""}
+	if len(this.config.ActivityInstanceDeploymentFilter) > 0 {
+		definitionIds = []string{}
+		for _, deploymentId := range this.config.ActivityInstanceDeploymentFilter {
+			key := getProcessDefinitionKey(deploymentId)
+			definitions, err := this.camunda.GetProcessDefinitionsByKeyLike(camunda.EscapeLike(key)+"%", UserId)
+			if err != nil {
+				return nil, err
+			}
+			for _, definition := range definitions {
+				if isProcessDefinitionKeyOf(definition.Key, key) && !slices.Contains(definitionIds, definition.Id) {
+					definitionIds = append(definitionIds, definition.Id)
+				}
+			}
+		}
+	}
+	activityTypes := []string{""}
+	if len(this.config.ActivityInstanceTypeFilter) > 0 {
+		activityTypes = this.config.ActivityInstanceTypeFilter
+	}
+	for _, definitionId := range definitionIds {
+		for _, activityType := range activityTypes {
+			query := url.Values{}
+			if definitionId != "" {
+				query.Set("processDefinitionId", definitionId)
+			}
+			if activityType != "" {
+				query.Set("activityType", activityType)
+			}
+			instances, err := this.camunda.GetFilteredActivityInstanceHistoryList(UserId, query)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, instances...)
+		}
+	}
+	return result, nil
+}
+
+// activityInstanceIsSynced applies activity_instance_deployment_filter and activity_instance_type_filter
+func (this *Controller) activityInstanceIsSynced(processDefinitionKey string, activityType string) bool {
+	if len(this.config.ActivityInstanceTypeFilter) > 0 && !slices.Contains(this.config.ActivityInstanceTypeFilter, activityType) {
+		return false
+	}
+	if len(this.config.ActivityInstanceDeploymentFilter) == 0 {
+		return true
+	}
+	for _, deploymentId := range this.config.ActivityInstanceDeploymentFilter {
+		if isProcessDefinitionKeyOf(processDefinitionKey, getProcessDefinitionKey(deploymentId)) {
+			return true
+		}
+	}
+	return false
+}
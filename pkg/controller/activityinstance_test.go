@@ -0,0 +1,170 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func TestActivityInstanceIsSynced(t *testing.T) {
+	ctrl := &Controller{config: configuration.Config{
+		ActivityInstanceDeploymentFilter: []string{"dep-1"},
+		ActivityInstanceTypeFilter:       []string{"serviceTask", "userTask"},
+	}}
+	tests := []struct {
+		key          string
+		activityType string
+		expected     bool
+	}{
+		{key: "deplid_dep_1", activityType: "serviceTask", expected: true},
+		{key: "deplid_dep_1_1", activityType: "userTask", expected: true},
+		{key: "deplid_dep_1", activityType: "startEvent", expected: false},
+		{key: "deplid_dep_2", activityType: "serviceTask", expected: false},
+		{key: "deplid_dep_10", activityType: "serviceTask", expected: false},
+		{key: "deplid_dep_1_def", activityType: "serviceTask", expected: false},
+		{key: "deplid_dep_1_", activityType: "serviceTask", expected: false},
+	}
+	for _, test := range tests {
+		if result := ctrl.activityInstanceIsSynced(test.key, test.activityType); result != test.expected {
+			t.Error(test.key, test.activityType, result)
+		}
+	}
+
+	ctrl.config = configuration.Config{}
+	if !ctrl.activityInstanceIsSynced("foo", "startEvent") {
+		t.Error("expected all activity instances without filter")
+	}
+}
+
+// fakeActivityInstances is a minimal camunda history api with paging
+type fakeActivityInstances struct {
+	mux         sync.Mutex
+	definitions camundamodel.ProcessDefinitions
+	instances   []camundamodel.HistoricActivityInstance
+	queries     []string
+}
+
+func (this *fakeActivityInstances) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	query := request.URL.Query()
+	switch request.URL.Path {
+	case "/engine-rest/process-definition":
+		result := camundamodel.ProcessDefinitions{}
+		for _, definition := range this.definitions {
+			if matchLike(query.Get("keyLike"), definition.Key) {
+				result = append(result, definition)
+			}
+		}
+		json.NewEncoder(writer).Encode(result)
+	case "/engine-rest/history/activity-instance":
+		this.queries = append(this.queries, request.URL.RawQuery)
+		matching := []camundamodel.HistoricActivityInstance{}
+		for _, instance := range this.instances {
+			if (query.Get("processDefinitionId") == "" || query.Get("processDefinitionId") == instance.ProcessDefinitionId) &&
+				(query.Get("activityType") == "" || query.Get("activityType") == instance.ActivityType) {
+				matching = append(matching, instance)
+			}
+		}
+		first, _ := strconv.Atoi(query.Get("firstResult"))
+		max, _ := strconv.Atoi(query.Get("maxResults"))
+		first = min(first, len(matching))
+		json.NewEncoder(writer).Encode(matching[first:min(first+max, len(matching))])
+	default:
+		http.NotFound(writer, request)
+	}
+}
+
+// matchLike matches the value with a sql like pattern using '\' as escape character
+func matchLike(pattern string, value string) bool {
+	expr := "^"
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			expr += regexp.QuoteMeta(string(c))
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '%':
+			expr += ".*"
+		case c == '_':
+			expr += "."
+		default:
+			expr += regexp.QuoteMeta(string(c))
+		}
+	}
+	return regexp.MustCompile(expr + "$").MatchString(value)
+}
+
+func TestGetCurrentActivityInstances(t *testing.T) {
+	fake := &fakeActivityInstances{
+		definitions: camundamodel.ProcessDefinitions{
+			{Id: "def1", Key: "deplid_dep_1"},
+			{Id: "def2", Key: "deplid_dep_2"},
+			{Id: "def3", Key: "deplid_dep_1_def"}, //deployment dep-1-def
+			{Id: "def4", Key: "deplidXdepY1"},     //matches the unescaped like pattern
+		},
+	}
+	for i := 0; i < camunda.ActivityInstancePageSize+10; i++ {
+		fake.instances = append(fake.instances, camundamodel.HistoricActivityInstance{Id: fmt.Sprint("a", i), ProcessDefinitionId: "def1", ActivityType: "serviceTask"})
+	}
+	fake.instances = append(fake.instances,
+		camundamodel.HistoricActivityInstance{Id: "start", ProcessDefinitionId: "def1", ActivityType: "startEvent"},
+		camundamodel.HistoricActivityInstance{Id: "other", ProcessDefinitionId: "def2", ActivityType: "serviceTask"},
+		camundamodel.HistoricActivityInstance{Id: "other-deployment", ProcessDefinitionId: "def3", ActivityType: "serviceTask"},
+		camundamodel.HistoricActivityInstance{Id: "like-wildcard", ProcessDefinitionId: "def4", ActivityType: "serviceTask"},
+	)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	config := configuration.Config{
+		ActivityInstanceDeploymentFilter: []string{"dep-1"},
+		ActivityInstanceTypeFilter:       []string{"serviceTask"},
+	}
+	ctrl := &Controller{config: config, camunda: camunda.New(config, shards.Shards(server.URL))}
+
+	result, err := ctrl.getCurrentActivityInstances()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != camunda.ActivityInstancePageSize+10 {
+		t.Error(len(result))
+	}
+	for _, instance := range result {
+		if instance.ProcessDefinitionId != "def1" || instance.ActivityType != "serviceTask" {
+			t.Errorf("%#v", instance)
+		}
+	}
+	fake.mux.Lock()
+	defer fake.mux.Unlock()
+	if len(fake.queries) != 2 {
+		t.Error(fake.queries)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+)
+
+type queuedStart struct {
+	CamundaDeploymentId string
+	BusinessKey         string
+	Parameter           map[string]interface{}
+}
+
+type startQueueLock struct {
+	mux   sync.Mutex
+	users int
+}
+
+func getStartQueueKey(processDefinitionId string, businessKey string) string {
+	return processDefinitionId + "\n" + businessKey
+}
+
+// lockStartQueue serializes start decisions for the business key without holding startQueueMux during camunda requests
+func (this *Controller) lockStartQueue(queueKey string) (unlock func()) {
+	this.startQueueMux.Lock()
+	if this.startQueueLocks == nil {
+		this.startQueueLocks = map[string]*startQueueLock{}
+	}
+	lock, ok := this.startQueueLocks[queueKey]
+	if !ok {
+		lock = &startQueueLock{}
+		this.startQueueLocks[queueKey] = lock
+	}
+	lock.users++
+	this.startQueueMux.Unlock()
+	lock.mux.Lock()
+	return func() {
+		lock.mux.Unlock()
+		this.startQueueMux.Lock()
+		defer this.startQueueMux.Unlock()
+		lock.users--
+		if lock.users == 0 {
+			delete(this.startQueueLocks, queueKey)
+		}
+	}
+}
+
+func (this *Controller) getStartQueueLength(queueKey string) int {
+	this.startQueueMux.Lock()
+	defer this.startQueueMux.Unlock()
+	return len(this.startQueue[queueKey])
+}
+
+// applyBusinessKeyPolicy starts the process depending on running instances with the same business key
+// the queue is only held in memory; queued starts are lost on restart
+func (this *Controller) applyBusinessKeyPolicy(processDefinitionId string, camundaDeploymentId string, policy string, businessKey string, parameter map[string]interface{}) (decision model.BusinessKeyDecision, err error) {
+	decision = model.BusinessKeyDecision{
+		CamundaDeploymentId: camundaDeploymentId,
+		BusinessKey:         businessKey,
+		Policy:              policy,
+	}
+	defer func() {
+		if err != nil {
+			decision.Error = err.Error()
+		}
+	}()
+
+	queueKey := getStartQueueKey(processDefinitionId, businessKey)
+	unlock := this.lockStartQueue(queueKey)
+	defer unlock()
+
+	running, err := this.camunda.GetProcessInstancesByBusinessKey(processDefinitionId, businessKey, UserId)
+	if err != nil {
+		return decision, err
+	}
+	for _, instance := range running {
+		decision.RunningInstanceIds = append(decision.RunningInstanceIds, instance.Id)
+	}
+	if len(running) == 0 && this.getStartQueueLength(queueKey) == 0 {
+		decision.Decision = model.BusinessKeyDecisionStarted
+		return decision, this.camunda.StartProcess(processDefinitionId, businessKey, UserId, parameter)
+	}
+
+	switch policy {
+	case model.BusinessKeyPolicyReject:
+		decision.Decision = model.BusinessKeyDecisionRejected
+		return decision, fmt.Errorf("process instance with business key '%v' is already running", businessKey)
+	case model.BusinessKeyPolicyReplace:
+		for _, instance := range running {
+			err = this.camunda.RemoveProcessInstance(instance.Id, UserId)
+			if err != nil {
+				return decision, err
+			}
+		}
+		decision.Decision = model.BusinessKeyDecisionReplaced
+		return decision, this.camunda.StartProcess(processDefinitionId, businessKey, UserId, parameter)
+	case model.BusinessKeyPolicyQueue:
+		this.startQueueMux.Lock()
+		if this.startQueue == nil {
+			this.startQueue = map[string][]queuedStart{}
+		}
+		this.startQueue[queueKey] = append(this.startQueue[queueKey], queuedStart{
+			CamundaDeploymentId: camundaDeploymentId,
+			BusinessKey:         businessKey,
+			Parameter:           parameter,
+		})
+		this.startQueueMux.Unlock()
+		decision.Decision = model.BusinessKeyDecisionQueued
+		return decision, nil
+	default:
+		return decision, fmt.Errorf("unknown business key policy '%v'", policy)
+	}
+}
+
+// startQueued starts the next queued start for the business key if no instance with this business key is running
+// returns nil if nothing has been started
+func (this *Controller) startQueued(processDefinitionId string, businessKey string) *model.BusinessKeyDecision {
+	if businessKey == "" {
+		return nil
+	}
+	queueKey := getStartQueueKey(processDefinitionId, businessKey)
+	if this.getStartQueueLength(queueKey) == 0 {
+		return nil
+	}
+	unlock := this.lockStartQueue(queueKey)
+	defer unlock()
+	running, err := this.camunda.GetProcessInstancesByBusinessKey(processDefinitionId, businessKey, UserId)
+	if err != nil {
+		log.Println("ERROR: unable to check running instances for queued start", businessKey, err)
+		return nil
+	}
+	if len(running) > 0 {
+		return nil
+	}
+	this.startQueueMux.Lock()
+	queue := this.startQueue[queueKey]
+	if len(queue) == 0 {
+		this.startQueueMux.Unlock()
+		return nil
+	}
+	next := queue[0]
+	if len(queue) == 1 {
+		delete(this.startQueue, queueKey)
+	} else {
+		this.startQueue[queueKey] = queue[1:]
+	}
+	this.startQueueMux.Unlock()
+	decision := &model.BusinessKeyDecision{
+		CamundaDeploymentId: next.CamundaDeploymentId,
+		BusinessKey:         businessKey,
+		Policy:              model.BusinessKeyPolicyQueue,
+		Decision:            model.BusinessKeyDecisionStarted,
+	}
+	err = this.camunda.StartProcess(processDefinitionId, businessKey, UserId, next.Parameter)
+	if err != nil {
+		log.Println("ERROR: unable to start queued process", next.CamundaDeploymentId, businessKey, err)
+		decision.Error = err.Error()
+	}
+	return decision
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// fakeInstances is a minimal camunda process-instance api
+type fakeInstances struct {
+	mux       sync.Mutex
+	running   map[string]string //instance id -> business key
+	started   []string          //business keys
+	removed   []string          //instance ids
+	idCounter int
+}
+
+func (this *fakeInstances) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	switch {
+	case request.Method == http.MethodGet && request.URL.Path == "/engine-rest/process-instance":
+		result := camundamodel.ProcessInstances{}
+		for id, key := range this.running {
+			if filter, ok := request.URL.Query()["businessKey"]; ok && filter[0] != key {
+				continue
+			}
+			result = append(result, camundamodel.ProcessInstance{Id: id, BusinessKey: key})
+		}
+		json.NewEncoder(writer).Encode(result)
+	case request.Method == http.MethodGet && request.URL.Path == "/engine-rest/process-definition":
+		json.NewEncoder(writer).Encode(camundamodel.ProcessDefinitions{{Id: "def"}})
+	case request.Method == http.MethodDelete && strings.HasPrefix(request.URL.Path, "/engine-rest/process-instance/"):
+		id := strings.TrimPrefix(request.URL.Path, "/engine-rest/process-instance/")
+		delete(this.running, id)
+		this.removed = append(this.removed, id)
+	case strings.HasSuffix(request.URL.Path, "/submit-form"):
+		msg := map[string]interface{}{}
+		json.NewDecoder(request.Body).Decode(&msg)
+		key, _ := msg["businessKey"].(string)
+		this.idCounter++
+		this.running[fmt.Sprint("i", this.idCounter)] = key
+		this.started = append(this.started, key)
+	default:
+		http.NotFound(writer, request)
+	}
+}
+
+func TestApplyBusinessKeyPolicy(t *testing.T) {
+	fake := &fakeInstances{running: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	config := configuration.Config{}
+	ctrl := &Controller{config: config, camunda: camunda.New(config, shards.Shards(server.URL))}
+
+	check := func(t *testing.T, decision model.BusinessKeyDecision, err error, expectedDecision string, expectErr bool) {
+		t.Helper()
+		if (err != nil) != expectErr {
+			t.Error(err)
+		}
+		if decision.Decision != expectedDecision {
+			t.Errorf("%#v", decision)
+		}
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		decision, err := ctrl.applyBusinessKeyPolicy("def", "c1", model.BusinessKeyPolicyReject, "room1", nil)
+		check(t, decision, err, model.BusinessKeyDecisionStarted, false)
+		decision, err = ctrl.applyBusinessKeyPolicy("def", "c1", model.BusinessKeyPolicyReject, "room1", nil)
+		check(t, decision, err, model.BusinessKeyDecisionRejected, true)
+		if len(decision.RunningInstanceIds) != 1 || decision.Error == "" {
+			t.Errorf("%#v", decision)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		decision, err := ctrl.applyBusinessKeyPolicy("def", "c1", model.BusinessKeyPolicyReplace, "room1", nil)
+		check(t, decision, err, model.BusinessKeyDecisionReplaced, false)
+		fake.mux.Lock()
+		defer fake.mux.Unlock()
+		if len(fake.removed) != 1 || len(fake.running) != 1 {
+			t.Error(fake.removed, fake.running)
+		}
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		decision, err := ctrl.applyBusinessKeyPolicy("def", "c1", model.BusinessKeyPolicyQueue, "room1", nil)
+		check(t, decision, err, model.BusinessKeyDecisionQueued, false)
+		decision, err = ctrl.applyBusinessKeyPolicy("def", "c1", model.BusinessKeyPolicyQueue, "room1", nil)
+		check(t, decision, err, model.BusinessKeyDecisionQueued, false)
+
+		if started := ctrl.startQueued("def", "room1"); started != nil {
+			t.Error("instance is still running", started)
+		}
+
+		fake.mux.Lock()
+		fake.running = map[string]string{}
+		fake.mux.Unlock()
+
+		//the queue must be empty before new starts are executed directly
+		decision, err = ctrl.applyBusinessKeyPolicy("def", "c1", model.BusinessKeyPolicyQueue, "room1", nil)
+		check(t, decision, err, model.BusinessKeyDecisionQueued, false)
+
+		started := ctrl.startQueued("def", "room1")
+		if started == nil || started.Decision != model.BusinessKeyDecisionStarted || started.Error != "" {
+			t.Errorf("%#v", started)
+		}
+		if len(ctrl.startQueue[getStartQueueKey("def", "room1")]) != 2 {
+			t.Error(ctrl.startQueue)
+		}
+	})
+
+	t.Run("no business key", func(t *testing.T) {
+		if started := ctrl.startQueued("def", ""); started != nil {
+			t.Error(started)
+		}
+	})
+
+	if len(ctrl.startQueueLocks) != 0 {
+		t.Error("unused start queue locks", ctrl.startQueueLocks)
+	}
+}
@@ -22,9 +22,11 @@ import (
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/rewrite"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/events"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
 	"github.com/SENERGY-Platform/service-commons/pkg/cache"
 	"log"
 	"sync"
@@ -34,11 +36,27 @@ import (
 const UserId = model.UserId
 
 func New(config configuration.Config, ctx context.Context) (ctrl *Controller, err error) {
+	err = rewrite.Validate(config.DeploymentRewriteRules)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateRedactionRules(config.VariableRedactionRules)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateSyncFilters(append(append([]configuration.SyncFilter{}, config.SyncInclude...), config.SyncExclude...))
+	if err != nil {
+		return nil, err
+	}
 	c, err := cache.New(cache.Config{}) //if the worker is scaled, the l2 must be configured with a shared memcached
 	if err != nil {
 		return nil, err
 	}
-	ctrl = &Controller{config: config, incidentsHandler: map[string]OnIncident{}, handledIncidentsCache: c}
+	instanceCache, err := cache.New(cache.Config{})
+	if err != nil {
+		return nil, err
+	}
+	ctrl = &Controller{config: config, incidentsHandler: map[string]OnIncident{}, handledIncidentsCache: c, instanceCache: instanceCache}
 
 	ctrl.metadata, err = metadata.NewStorage(ctx, config)
 	if err != nil {
@@ -50,7 +68,9 @@ func New(config configuration.Config, ctx context.Context) (ctrl *Controller, er
 	if err != nil {
 		return ctrl, err
 	}
-	ctrl.events, err = events.StartApi(ctx, config)
+	ctrl.fullSync = ctrl.backend.WithSource(model.StateSourceFullSync)
+	ctrl.command = ctrl.backend.WithSource(model.StateSourceCommand)
+	ctrl.events, err = events.StartApi(ctx, config, ctrl)
 	if err != nil {
 		return ctrl, err
 	}
@@ -72,6 +92,8 @@ func New(config configuration.Config, ctx context.Context) (ctrl *Controller, er
 		}
 	}
 
+	ctrl.startKpi(ctx)
+
 	err = ctrl.spyOnCamundaDb(ctx)
 	if err != nil {
 		return ctrl, err
@@ -102,6 +124,10 @@ func New(config configuration.Config, ctx context.Context) (ctrl *Controller, er
 			}()
 		}
 	}
+	ctrl.startScheduler(ctx)
+	ctrl.ensureKeepAliveDeployments()
+	ctrl.startWatchdog(ctx)
+	ctrl.startExternalTaskSummary(ctx)
 	return ctrl, ctrl.SendCurrentStates()
 }
 
@@ -113,12 +139,39 @@ type EventRepo interface {
 type Controller struct {
 	config                configuration.Config
 	backend               *backend.Client
+	fullSync              *backend.Client //backend marking states as sent by the full sync
+	command               *backend.Client //backend marking states as caused by commands; used by the Handler methods
 	camunda               *camunda.Camunda
 	metadata              metadata.Storage
 	events                EventRepo
 	incidentsHandler      map[string]OnIncident
 	handledIncidentsCache *cache.Cache
+	instanceCache         *cache.Cache //call hierarchy links by process instance id and process instances by execution id; see getInstanceLinks and getSuperProcessInstanceId
 	mux                   sync.Mutex
+	metadataMux           sync.Mutex
+	startQueue            map[string][]queuedStart    //by process definition id and business key; see BusinessKeyPolicyQueue
+	startQueueLocks       map[string]*startQueueLock  //by process definition id and business key; removed when unused
+	startQueueMux         sync.Mutex                  //guards startQueue and startQueueLocks
+	keepAlive             map[string]*keepAliveState  //by camunda deployment id
+	keepAliveMux          sync.Mutex                  //guards keepAlive and keepAliveDeployments; see keepAliveState.mux
+	keepAliveDeployments  *keepAliveDeployments       //nil if not loaded; see listKeepAliveDeployments
+	keepAliveGeneration   int64                       //incremented by invalidateKeepAlive
+	watchedInstances      map[string]*watchedInstance //by process instance id
+	watchdogMux           sync.Mutex
+	syncedVariables       map[string][]string //synced_variables by process definition key
+	syncedVariablesUpdate time.Time
+	syncedVariablesMux    sync.Mutex
+
+	externalTaskFirstSeen    map[string]time.Time //by external task id
+	externalTaskFirstSeenMux sync.Mutex
+
+	definitions    map[string]camundamodel.ProcessDefinition //by process definition id; see getProcessDefinition
+	definitionsMux sync.Mutex
+
+	kpi *kpiAggregator //nil if disabled
+
+	syncFilterCache map[string]bool //by camunda deployment id; see isSyncedDeployment
+	syncFilterMux   sync.Mutex
 }
 
 func (this *Controller) SendCurrentStates() (err error) {
@@ -138,6 +191,22 @@ func (this *Controller) SendCurrentStates() (err error) {
 	if err != nil {
 		return err
 	}
+	err = this.SendCurrentActivityInstances()
+	if err != nil {
+		return err
+	}
+	err = this.SendCurrentExternalTasks()
+	if err != nil {
+		return err
+	}
+	err = this.SendCurrentJobs()
+	if err != nil {
+		return err
+	}
+	err = this.SendCurrentUserTasks()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression with the five standard fields: minute hour day-of-month month day-of-week
+// fields may be '*', numbers, ranges (1-5), lists (1,2,3) and steps (*/15, 0-30/10)
+// if day-of-month and day-of-week are both restricted, a time matches if either matches (like the standard cron)
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var fieldBounds = []bounds{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day-of-month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day-of-week", min: 0, max: 7},
+}
+
+func Parse(expr string) (result Schedule, err error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := macros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(fieldBounds) {
+		return result, fmt.Errorf("invalid cron expression '%v': expected %v fields", expr, len(fieldBounds))
+	}
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		values[i], err = parseField(field, fieldBounds[i])
+		if err != nil {
+			return result, fmt.Errorf("invalid cron expression '%v': %w", expr, err)
+		}
+	}
+	result = Schedule{
+		minute:  values[0],
+		hour:    values[1],
+		dom:     values[2],
+		month:   values[3],
+		dow:     values[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	//sunday may be 0 or 7
+	if result.dow&(1<<7) != 0 {
+		result.dow = result.dow | 1
+	}
+	return result, nil
+}
+
+func parseField(field string, b bounds) (result uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step '%v' in %v", stepPart, b.name)
+			}
+		}
+		start, end := b.min, b.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			startStr, endStr, _ := strings.Cut(rangePart, "-")
+			start, err = strconv.Atoi(startStr)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value '%v' in %v", startStr, b.name)
+			}
+			end, err = strconv.Atoi(endStr)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value '%v' in %v", endStr, b.name)
+			}
+		default:
+			start, err = strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value '%v' in %v", rangePart, b.name)
+			}
+			end = start
+			if hasStep {
+				end = b.max
+			}
+		}
+		if start < b.min || end > b.max || start > end {
+			return 0, fmt.Errorf("value '%v' out of range %v-%v in %v", rangePart, b.min, b.max, b.name)
+		}
+		for i := start; i <= end; i = i + step {
+			result = result | 1<<uint(i)
+		}
+	}
+	if result == 0 {
+		return 0, errors.New("empty " + b.name)
+	}
+	return result, nil
+}
+
+// Next returns the first matching time after t (in the location of t)
+// returns the zero time if no matching time is found within the next 5 years (e.g. 30th of february)
+func (this Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if this.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !this.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if this.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if this.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (this Schedule) dayMatches(t time.Time) bool {
+	domMatch := this.dom&(1<<uint(t.Day())) != 0
+	dowMatch := this.dow&(1<<uint(t.Weekday())) != 0
+	if this.domStar || this.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
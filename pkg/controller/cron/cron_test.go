@@ -0,0 +1,79 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	//2026-01-14 is a wednesday
+	start := time.Date(2026, 1, 14, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{expr: "* * * * *", expected: time.Date(2026, 1, 14, 10, 8, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", expected: time.Date(2026, 1, 14, 10, 15, 0, 0, time.UTC)},
+		{expr: "0 * * * *", expected: time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", expected: time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)},
+		{expr: "30 6 * * *", expected: time.Date(2026, 1, 15, 6, 30, 0, 0, time.UTC)},
+		{expr: "0 8-18/5 * * *", expected: time.Date(2026, 1, 14, 13, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 1-5", expected: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 0", expected: time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", expected: time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 * *", expected: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1,15 * *", expected: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 * 6", expected: time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)}, //day-of-month or day-of-week
+		{expr: "0 0 29 2 *", expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", expected: time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			schedule, err := Parse(test.expr)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if next := schedule.Next(start); !next.Equal(test.expected) {
+				t.Error(next, test.expected)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Error("expected error for", expr)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func (this *Controller) EvaluateDecision(decisionKey string, variables map[string]interface{}) ([]map[string]camundamodel.Variable, error) {
+	if decisionKey == "" {
+		return nil, errors.New("missing decision key")
+	}
+	variables, err := NormalizeVariables(variables)
+	if err != nil {
+		return nil, err
+	}
+	return this.camunda.EvaluateDecision(decisionKey, UserId, variables)
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// fakeDecisions is a minimal camunda decision-definition api
+type fakeDecisions struct {
+	mux       sync.Mutex
+	decisions map[string][]map[string]camundamodel.Variable //by decision key
+	paths     []string                                      //escaped request paths
+	variables []map[string]camundamodel.Variable
+}
+
+func (this *fakeDecisions) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.paths = append(this.paths, request.URL.EscapedPath())
+	body := struct {
+		Variables map[string]camundamodel.Variable `json:"variables"`
+	}{}
+	err := json.NewDecoder(request.Body).Decode(&body)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	this.variables = append(this.variables, body.Variables)
+	for key, result := range this.decisions {
+		if request.Method == http.MethodPost && request.URL.EscapedPath() == "/engine-rest/decision-definition/key/"+url.PathEscape(key)+"/tenant-id/"+url.PathEscape(UserId)+"/evaluate" {
+			json.NewEncoder(writer).Encode(result)
+			return
+		}
+	}
+	http.Error(writer, `{"type":"RestException","message":"no decision definition"}`, http.StatusNotFound)
+}
+
+func TestEvaluateDecision(t *testing.T) {
+	fake := &fakeDecisions{decisions: map[string][]map[string]camundamodel.Variable{
+		"heating/level 1": {{"level": {Type: "Integer", Value: 3.0}}},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	config := configuration.Config{}
+	ctrl := &Controller{config: config, camunda: camunda.New(config, shards.Shards(server.URL))}
+
+	result, err := ctrl.EvaluateDecision("heating/level 1", map[string]interface{}{
+		"room":        "lab",
+		"temperature": camundamodel.Variable{Type: "Double", Value: "21.5"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != 1 || result[0]["level"].Value != 3.0 {
+		t.Errorf("%#v", result)
+	}
+
+	fake.mux.Lock()
+	if len(fake.paths) != 1 || fake.paths[0] != "/engine-rest/decision-definition/key/heating%2Flevel%201/tenant-id/"+url.PathEscape(UserId)+"/evaluate" {
+		t.Error(fake.paths)
+	}
+	//plain values are sent untyped, typed values are converted to the camunda type
+	if variable := fake.variables[0]["room"]; variable.Type != "" || variable.Value != "lab" {
+		t.Errorf("%#v", variable)
+	}
+	if variable := fake.variables[0]["temperature"]; variable.Type != "Double" || variable.Value != 21.5 {
+		t.Errorf("%#v", variable)
+	}
+	fake.mux.Unlock()
+
+	_, err = ctrl.EvaluateDecision("unknown", nil)
+	if err == nil {
+		t.Error("expected error for unknown decision")
+	}
+	_, err = ctrl.EvaluateDecision("heating/level 1", map[string]interface{}{"temperature": camundamodel.Variable{Type: "Double", Value: "warm"}})
+	if err == nil {
+		t.Error("expected error for invalid typed variable")
+	}
+	_, err = ctrl.EvaluateDecision("", nil)
+	if err == nil {
+		t.Error("expected error for missing decision key")
+	}
+}
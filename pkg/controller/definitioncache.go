@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"log"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/service-commons/pkg/cache"
+)
+
+const instanceLinksExpiration = time.Hour
+
+// instanceLinks are the call hierarchy links of a process instance; they do not change after the start of the instance
+// the business key is cached with them; business keys changed by the process are picked up after instanceLinksExpiration
+type instanceLinks struct {
+	SuperProcessInstanceId string
+	RootProcessInstanceId  string
+	ProcessDefinitionId    string
+	BusinessKey            string
+}
+
+// getProcessDefinition returns the process definition from the definition cache or camunda
+// the cache is invalidated by the ACT_RE_PROCDEF and ACT_RE_DEPLOYMENT notifications
+func (this *Controller) getProcessDefinition(id string) (camundamodel.ProcessDefinition, error) {
+	this.definitionsMux.Lock()
+	definition, ok := this.definitions[id]
+	this.definitionsMux.Unlock()
+	if ok {
+		return definition, nil
+	}
+	definition, err := this.camunda.GetProcessDefinition(id, UserId)
+	if err != nil {
+		return definition, err
+	}
+	this.cacheProcessDefinition(definition)
+	return definition, nil
+}
+
+func (this *Controller) cacheProcessDefinition(definition camundamodel.ProcessDefinition) {
+	this.definitionsMux.Lock()
+	defer this.definitionsMux.Unlock()
+	if this.definitions == nil {
+		this.definitions = map[string]camundamodel.ProcessDefinition{}
+	}
+	this.definitions[definition.Id] = definition
+}
+
+func (this *Controller) invalidateProcessDefinition(id string) {
+	this.definitionsMux.Lock()
+	defer this.definitionsMux.Unlock()
+	delete(this.definitions, id)
+}
+
+func (this *Controller) invalidateDeploymentDefinitions(deploymentId string) {
+	this.definitionsMux.Lock()
+	defer this.definitionsMux.Unlock()
+	for id, definition := range this.definitions {
+		if definition.DeploymentId == deploymentId {
+			delete(this.definitions, id)
+		}
+	}
+}
+
+// getDeploymentIdOfDefinition returns the camunda deployment id of the process definition
+func (this *Controller) getDeploymentIdOfDefinition(definitionId string) string {
+	if definitionId == "" {
+		return ""
+	}
+	definition, err := this.getProcessDefinition(definitionId)
+	if err != nil {
+		log.Println("WARNING: unable to get process definition", definitionId, err)
+		return ""
+	}
+	return definition.DeploymentId
+}
+
+// getInstanceLinks returns the links of the historic process instance from the instance cache or camunda
+// the cache is invalidated by the ACT_HI_PROCINST delete notification
+func (this *Controller) getInstanceLinks(instanceId string) (instanceLinks, error) {
+	return cache.Use[instanceLinks](this.instanceCache, getInstanceLinksCacheKey(instanceId), func() (instanceLinks, error) {
+		history, err := this.camunda.GetHistoricProcessInstance(instanceId, UserId)
+		if err != nil {
+			return instanceLinks{}, err
+		}
+		return instanceLinks{
+			SuperProcessInstanceId: history.SuperProcessInstanceId,
+			RootProcessInstanceId:  history.RootProcessInstanceId,
+			ProcessDefinitionId:    history.ProcessDefinitionId,
+			BusinessKey:            history.BusinessKey,
+		}, nil
+	}, cache.NoValidation, instanceLinksExpiration)
+}
+
+func (this *Controller) invalidateInstanceLinks(instanceId string) {
+	if this.instanceCache == nil {
+		return
+	}
+	_ = this.instanceCache.Remove(getInstanceLinksCacheKey(instanceId)) //not found errors are expected
+}
+
+func getInstanceLinksCacheKey(instanceId string) string {
+	return "instance-links:" + instanceId
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func TestDefinitionCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if !strings.HasPrefix(request.URL.Path, "/engine-rest/process-definition/") {
+			http.NotFound(writer, request)
+			return
+		}
+		requests++
+		id := strings.TrimPrefix(request.URL.Path, "/engine-rest/process-definition/")
+		json.NewEncoder(writer).Encode(camundamodel.ProcessDefinition{Id: id, DeploymentId: "depl_" + strings.Split(id, ":")[0]})
+	}))
+	defer server.Close()
+	config := configuration.Config{}
+	ctrl := &Controller{config: config, camunda: camunda.New(config, shards.Shards(server.URL))}
+
+	lookup := func(id string, expectedRequests int) {
+		t.Helper()
+		definition, err := ctrl.getProcessDefinition(id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if definition.Id != id {
+			t.Errorf("%#v", definition)
+		}
+		if requests != expectedRequests {
+			t.Error(requests, expectedRequests)
+		}
+	}
+
+	lookup("a:1", 1)
+	lookup("a:1", 1)
+	lookup("a:2", 2)
+	lookup("b:1", 3)
+
+	ctrl.invalidateProcessDefinition("a:1")
+	lookup("a:1", 4)
+	lookup("a:2", 4)
+
+	ctrl.invalidateDeploymentDefinitions("depl_a")
+	lookup("b:1", 4)
+	lookup("a:1", 5)
+	lookup("a:2", 6)
+}
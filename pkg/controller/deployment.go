@@ -17,12 +17,14 @@
 package controller
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/etree"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/rewrite"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
@@ -32,38 +34,63 @@ import (
 	"strings"
 )
 
+// CreateDeployment deploys the process to camunda
+// a redelivered deployment (same id and content as an existing camunda deployment) is not redeployed; only the current state is resent
+// states are sent with the command source
 func (this *Controller) CreateDeployment(deployment model.FogDeploymentMessage) (id string, err error) {
-	err = this.cleanupExistingDeployment(deployment.Id)
+	hash, err := getDeploymentHash(deployment)
 	if err != nil {
 		return "", err
 	}
-	xml := deployment.Diagram.XmlDeployed
-
-	xml, err = SetProcessId(xml, deployment.Id)
+	existing, found, err := this.findDeploymentWithHash(deployment.Id, hash)
 	if err != nil {
-		return "", err
+		log.Println("WARNING: unable to check for existing deployment", deployment.Id, err)
 	}
-
-	xml = this.replaceNotificationUrl(xml)
-
-	xml, err = ReplaceTaskTopics(xml, this.config.TaskTopicReplace)
+	if found {
+		log.Println("deployment", deployment.Id, "already deployed as", existing.CamundaDeploymentId, "--> resend current state")
+		return existing.CamundaDeploymentId, this.resendDeployment(existing)
+	}
+	md, err := this.createDeployment(deployment, hash)
 	if err != nil {
-		return "", err
+		return md.CamundaDeploymentId, err
 	}
+	this.sendLabelFilteredDeploymentStates(md.CamundaDeploymentId)
+	if this.isSyncedDeployment(md.CamundaDeploymentId) {
+		err = this.command.SendDeploymentMetadata(md)
+	}
+	this.ensureKeepAlive(md)
+	return md.CamundaDeploymentId, err
+}
 
+// createDeployment deploys the process and stores its metadata
+// the caller sends the metadata and ensures the keep-alive of the new deployment
+func (this *Controller) createDeployment(deployment model.FogDeploymentMessage, hash string) (result metadata.Metadata, err error) {
+	prepared := this.prepareDeployment(deployment)
+	xml := prepared.Xml
 	svg := deployment.Diagram.Svg
-	if !validateXml(xml) {
-		log.Println("ERROR: got invalid xml, replace with default")
+	resources := deployment.Resources
+	if len(prepared.ValidationErrors) > 0 {
+		if !this.config.InvalidDeploymentFallbackToBlank {
+			log.Println("ERROR: got invalid deployment", deployment.Id, prepared.ValidationErrors)
+			return result, prepared.ValidationErrors
+		}
+		log.Println("ERROR: got invalid xml, replace with default", prepared.ValidationErrors)
 		xml = camunda.CreateBlankProcess()
 		svg = camunda.CreateBlankSvg()
+		resources = nil
+	}
+
+	err = this.cleanupExistingDeployment(deployment.Id)
+	if err != nil {
+		return result, err
 	}
 	if this.config.Debug {
 		log.Println("deploy process", deployment.Id, deployment.Name, xml)
 	}
-	id, err = this.camunda.DeployProcess(deployment.Name, xml, svg, UserId, "senergy")
+	id, err := this.camunda.DeployProcess(deployment.Name, xml, svg, resources, UserId, "senergy")
 	if err != nil {
 		log.Println("WARNING: unable to deploy process to camunda ", err)
-		return "", err
+		return result, err
 	}
 
 	incidentHandling := deployment.IncidentHandling
@@ -74,7 +101,7 @@ func (this *Controller) CreateDeployment(deployment model.FogDeploymentMessage)
 			if removeErr != nil {
 				log.Println("ERROR: unable to remove deployed process", id, removeErr, err)
 			}
-			return id, err
+			return metadata.Metadata{CamundaDeploymentId: id}, err
 		}
 	}
 
@@ -83,29 +110,164 @@ func (this *Controller) CreateDeployment(deployment model.FogDeploymentMessage)
 		DeploymentModel:     deployment,
 		ProcessParameter:    nil,
 		CamundaDeploymentId: id,
+		Resources:           []string{},
+		ContentHash:         hash,
+		XmlHash:             hashXml(xml),
+	}
+	for _, resource := range resources {
+		metadata.Resources = append(metadata.Resources, resource.Name)
 	}
 
 	metadata.ProcessParameter, err = this.getProcessParameter(id)
 	if err != nil {
 		log.Println("WARNING: unable to get process parameter:", err)
 	}
+	metadata.RequiredParameter = GetRequiredStartParameter(xml)
 
-	err = this.metadata.Store(metadata)
+	err = this.storeMetadata(metadata)
 	if err != nil {
 		log.Println("WARNING: unable to store deployment metadata:", err)
 	}
+	this.invalidateKeepAlive(id)
 
 	err = this.DeployConditionalEventOperators(metadata)
 	if err != nil {
 		log.Println("ERROR: DeployConditionalEventOperators():", err)
-		return id, err
+		return metadata, err
+	}
+
+	this.invalidateSyncFilter(id)
+	return metadata, nil
+}
+
+func getDeploymentHash(deployment model.FogDeploymentMessage) (string, error) {
+	temp, err := json.Marshal(deployment)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(temp)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// findDeploymentWithHash returns the metadata of the camunda deployment created from the same deployment message
+func (this *Controller) findDeploymentWithHash(deploymentId string, hash string) (result metadata.Metadata, found bool, err error) {
+	if this.metadata.IsPlaceholder() {
+		return result, false, nil
+	}
+	list, err := this.metadata.List()
+	if err != nil {
+		return result, false, err
+	}
+	for _, md := range list {
+		if md.DeploymentModel.Id == deploymentId && md.ContentHash == hash {
+			_, err = this.camunda.GetDeployment(md.CamundaDeploymentId, UserId)
+			if err != nil {
+				//unknown in camunda
+				continue
+			}
+			return md, true, nil
+		}
 	}
+	return result, false, nil
+}
 
-	return id, this.backend.SendDeploymentMetadata(metadata)
+func (this *Controller) resendDeployment(md metadata.Metadata) error {
+	deployment, err := this.camunda.GetDeployment(md.CamundaDeploymentId, UserId)
+	if err != nil {
+		return err
+	}
+	if !this.isSyncedCamundaDeployment(deployment) {
+		return nil
+	}
+	err = this.command.SendDeploymentUpdate(deployment)
+	if err != nil {
+		return err
+	}
+	return this.command.SendDeploymentMetadata(md)
 }
 
-func (this *Controller) replaceNotificationUrl(xml string) string {
-	return strings.ReplaceAll(xml, this.config.NotificationUrlPlaceholder, this.config.NotificationUrl)
+// DryRunDeployment runs the transformation and validation of CreateDeployment without deploying the process
+func (this *Controller) DryRunDeployment(deployment model.FogDeploymentMessage) (result model.DeploymentDryRunResult) {
+	result = this.prepareDeployment(deployment)
+	if len(result.ValidationErrors) > 0 && this.config.InvalidDeploymentFallbackToBlank {
+		result.Warnings = append(result.Warnings, "invalid deployment would be replaced by a blank process")
+	}
+	return result
+}
+
+func (this *Controller) prepareDeployment(deployment model.FogDeploymentMessage) (result model.DeploymentDryRunResult) {
+	result = model.DeploymentDryRunResult{
+		DeploymentId: deployment.Id,
+		Rewrites:     []string{},
+		Warnings:     []string{},
+	}
+
+	xml, rewrites, err := setProcessId(deployment.Diagram.XmlDeployed, deployment.Id)
+	if err != nil {
+		result.ValidationErrors = model.ValidationErrors{{Rule: model.ValidationRuleXml, Message: err.Error()}}
+		return result
+	}
+	result.Rewrites = append(result.Rewrites, rewrites...)
+
+	xml, rewrites = this.replaceNotificationUrl(xml)
+	result.Rewrites = append(result.Rewrites, rewrites...)
+	if len(rewrites) > 0 && this.config.NotificationUrl == "" {
+		result.Warnings = append(result.Warnings, "notification url placeholder replaced with empty notification url")
+	}
+
+	xml, rewrites, err = replaceTaskTopics(xml, this.config.TaskTopicReplace)
+	if err != nil {
+		result.ValidationErrors = model.ValidationErrors{{Rule: model.ValidationRuleXml, Message: err.Error()}}
+		return result
+	}
+	result.Rewrites = append(result.Rewrites, rewrites...)
+
+	xml, rewrites, err = rewrite.Apply(xml, this.config.DeploymentRewriteRules)
+	if err != nil {
+		result.ValidationErrors = model.ValidationErrors{{Rule: model.ValidationRuleRewrite, Message: err.Error()}}
+		return result
+	}
+	result.Rewrites = append(result.Rewrites, rewrites...)
+
+	if len(deployment.EventDescriptions) > 0 && this.metadata.IsPlaceholder() {
+		result.Warnings = append(result.Warnings, "no metadata storage configured --> no message event handling")
+	}
+	if len(deployment.Schedules) > 0 && (this.metadata.IsPlaceholder() || !this.schedulerEnabled()) {
+		result.Warnings = append(result.Warnings, "no metadata storage or schedule_check_interval configured --> schedules are not executed")
+	}
+	if deployment.KeepAlive != nil && this.metadata.IsPlaceholder() {
+		result.Warnings = append(result.Warnings, "no metadata storage configured --> keep-alive deployment is started once but not restarted")
+	}
+	if deployment.Watchdog != nil && (this.metadata.IsPlaceholder() || !this.watchdogEnabled()) {
+		result.Warnings = append(result.Warnings, "no metadata storage or watchdog_check_interval configured --> watchdog is not executed")
+	}
+	if len(deployment.SyncedVariables) > 0 && (this.metadata.IsPlaceholder() || !this.config.SyncProcessVariables) {
+		result.Warnings = append(result.Warnings, "no metadata storage configured or sync_process_variables disabled --> variables are not synced")
+	}
+	if deployment.BusinessKeyPolicy != "" && this.metadata.IsPlaceholder() {
+		result.Warnings = append(result.Warnings, "no metadata storage configured --> business key policy is not applied")
+	}
+
+	result.Xml = xml
+	result.ValidationErrors = ValidateDeploymentXml(xml, this.config.KnownTaskTopics)
+	result.ValidationErrors = append(result.ValidationErrors, ValidateDeploymentResources(deployment.Name, deployment.Resources)...)
+	result.ValidationErrors = append(result.ValidationErrors, ValidateSchedules(deployment.Schedules)...)
+	result.ValidationErrors = append(result.ValidationErrors, ValidateBusinessKeyPolicy(deployment.BusinessKeyPolicy)...)
+	result.ValidationErrors = append(result.ValidationErrors, ValidateWatchdog(deployment.Watchdog)...)
+	result.ValidationErrors = append(result.ValidationErrors, ValidateSyncedVariables(deployment.SyncedVariables)...)
+	return result
+}
+
+func (this *Controller) replaceNotificationUrl(xml string) (result string, rewrites []string) {
+	if this.config.NotificationUrlPlaceholder == "" {
+		return xml, nil
+	}
+	count := strings.Count(xml, this.config.NotificationUrlPlaceholder)
+	if count == 0 {
+		return xml, nil
+	}
+	rewrites = append(rewrites, fmt.Sprintf("notification url: replaced %v placeholder(s) '%v' with '%v'", count, this.config.NotificationUrlPlaceholder, this.config.NotificationUrl))
+	return strings.ReplaceAll(xml, this.config.NotificationUrlPlaceholder, this.config.NotificationUrl), rewrites
 }
 
 func (this *Controller) getProcessParameter(deploymentId string) (result map[string]camundamodel.Variable, err error) {
@@ -123,15 +285,59 @@ func (this *Controller) DeleteDeployment(id string) error {
 	return this.camunda.RemoveProcess(id, UserId)
 }
 
+// GetDeploymentId returns the id of the deployment message the camunda deployment was created from
+// returns the camunda deployment id if no metadata is stored
+func (this *Controller) GetDeploymentId(camundaDeploymentId string) string {
+	md := this.getKnownMetadata(camundaDeploymentId)
+	if md.DeploymentModel.Id == "" {
+		return camundaDeploymentId
+	}
+	return md.DeploymentModel.Id
+}
+
+// StartDeployment starts the process for the start command; business key decisions are sent with the command source
 func (this *Controller) StartDeployment(id string, businessKey string, parameter map[string]interface{}) error {
+	decision, err := this.startDeployment(id, businessKey, parameter)
+	if decision != nil {
+		sendErr := this.command.SendBusinessKeyDecision(*decision)
+		if sendErr != nil {
+			log.Println("WARNING: unable to send business key decision", sendErr)
+		}
+	}
+	return err
+}
+
+// autoStartDeployment starts the process for keep-alive and schedules; business key decisions are sent with the trigger source
+func (this *Controller) autoStartDeployment(id string, businessKey string, parameter map[string]interface{}) error {
+	decision, err := this.startDeployment(id, businessKey, parameter)
+	if decision != nil {
+		sendErr := this.backend.SendBusinessKeyDecision(*decision)
+		if sendErr != nil {
+			log.Println("WARNING: unable to send business key decision", sendErr)
+		}
+	}
+	return err
+}
+
+// startDeployment returns the business key decision if the business key policy of the deployment was applied
+func (this *Controller) startDeployment(id string, businessKey string, parameter map[string]interface{}) (*model.BusinessKeyDecision, error) {
 	definitions, err := this.camunda.GetDefinitionByDeploymentVid(id, UserId)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(definitions) == 0 {
-		return fmt.Errorf("no definition for deployment '%s' found", id)
+		return nil, fmt.Errorf("no definition for deployment '%s' found", id)
+	}
+	md := this.getKnownMetadata(id)
+	parameter, err = CoerceStartParameter(md.ProcessParameter, md.RequiredParameter, parameter)
+	if err != nil {
+		return nil, err
 	}
-	return this.camunda.StartProcess(definitions[0].Id, businessKey, UserId, parameter)
+	if md.DeploymentModel.BusinessKeyPolicy == "" || businessKey == "" {
+		return nil, this.camunda.StartProcess(definitions[0].Id, businessKey, UserId, parameter)
+	}
+	decision, err := this.applyBusinessKeyPolicy(definitions[0].Id, id, md.DeploymentModel.BusinessKeyPolicy, businessKey, parameter)
+	return &decision, err
 }
 
 func (this *Controller) SendCurrentDeployments() error {
@@ -141,17 +347,21 @@ func (this *Controller) SendCurrentDeployments() error {
 	}
 	ids := []string{}
 	for _, depl := range deployments {
+		if !this.isSyncedCamundaDeployment(depl) {
+			continue
+		}
 		ids = append(ids, depl.Id)
-		err = this.backend.SendDeploymentUpdate(depl)
+		err = this.fullSync.SendDeploymentUpdate(depl)
 		if err != nil {
 			return err
 		}
 	}
-	err = this.backend.SendDeploymentKnownIds(ids)
+	err = this.fullSync.SendDeploymentKnownIds(ids)
 	if err != nil {
 		return err
 	}
-	knownmetadata, err := this.metadata.EnsureKnownDeployments(ids)
+	//deployments excluded from the sync are not missing in camunda
+	knownmetadata, err := this.checkDeploymentDrift(deployments)
 	if err != nil {
 		return err
 	}
@@ -160,7 +370,10 @@ func (this *Controller) SendCurrentDeployments() error {
 
 func (this *Controller) sendKnownDeploymentMetadata(knownmetadata []metadata.Metadata) error {
 	for _, metadata := range knownmetadata {
-		err := this.backend.SendDeploymentMetadata(metadata)
+		if !this.isSyncedDeployment(metadata.CamundaDeploymentId) {
+			continue
+		}
+		err := this.fullSync.SendDeploymentMetadata(metadata)
 		if err != nil {
 			return err
 		}
@@ -184,13 +397,19 @@ func (this *Controller) NotifyDeploymentUpdate(extra string) {
 		log.Println("ERROR: unable to unmarshal deployment in NotifyDeploymentUpdate(): ", err)
 		return
 	}
-	err = this.backend.SendDeploymentUpdate(camundamodel.Deployment{
+	this.invalidateDeploymentDefinitions(deployment.Id)
+	this.invalidateSyncFilter(deployment.Id)
+	depl := camundamodel.Deployment{
 		Id:             deployment.Id,
 		Name:           deployment.Name,
 		Source:         deployment.Source,
 		DeploymentTime: deployment.DeploymentTime,
 		TenantId:       deployment.TenantId,
-	})
+	}
+	if !this.isSyncedCamundaDeployment(depl) {
+		return
+	}
+	err = this.backend.SendDeploymentUpdate(depl)
 	if err != nil {
 		log.Println("ERROR: unable to send deployment update in NotifyDeploymentUpdate(): ", err)
 		return
@@ -204,14 +423,17 @@ func (this *Controller) NotifyDeploymentDelete(extra string) {
 		log.Println("ERROR: unable to unmarshal deployment in NotifyDeploymentDelete(): ", err)
 		return
 	}
+	this.invalidateDeploymentDefinitions(deployment.Id)
+	this.invalidateSyncFilter(deployment.Id)
 	err = this.backend.SendDeploymentDelete(deployment.Id)
 	if err != nil {
 		log.Println("ERROR: unable to send deployment delete in NotifyDeploymentDelete(): ", err)
 	}
-	err = this.metadata.Remove(deployment.Id)
+	err = this.removeMetadata(deployment.Id)
 	if err != nil {
 		log.Println("WARNING: unable to remove deployment metadata", err)
 	}
+	this.invalidateKeepAlive(deployment.Id)
 	err = this.RemoveConditionalEventOperators(deployment.Id)
 	if err != nil {
 		log.Println("WARNING: unable to remove event operator", err)
@@ -222,24 +444,12 @@ func (this *Controller) cleanupExistingDeployment(id string) error {
 	return this.DeleteDeployment(id)
 }
 
-func validateXml(xmlStr string) bool {
-	if xmlStr == "" {
-		return false
-	}
-	err := etree.NewDocument().ReadFromString(xmlStr)
-	if err != nil {
-		log.Println("ERROR: unable to parse xml", err)
-		return false
-	}
-	err = xml.Unmarshal([]byte(xmlStr), new(interface{}))
-	if err != nil {
-		log.Println("ERROR: unable to parse xml", err)
-		return false
-	}
-	return true
+func ReplaceTaskTopics(xml string, fromToMap map[string]string) (result string, err error) {
+	result, _, err = replaceTaskTopics(xml, fromToMap)
+	return result, err
 }
 
-func ReplaceTaskTopics(xml string, fromToMap map[string]string) (result string, err error) {
+func replaceTaskTopics(xml string, fromToMap map[string]string) (result string, rewrites []string, err error) {
 	defer func() {
 		if r := recover(); r != nil && err == nil {
 			log.Printf("%s: %s", r, debug.Stack())
@@ -249,20 +459,51 @@ func ReplaceTaskTopics(xml string, fromToMap map[string]string) (result string,
 	doc := etree.NewDocument()
 	err = doc.ReadFromString(xml)
 	if err != nil {
-		return result, err
+		return result, rewrites, err
 	}
 	for from, to := range fromToMap {
 		for _, element := range doc.FindElements("//bpmn:serviceTask[@camunda:topic='" + from + "']") {
 			attr := element.SelectAttr("camunda:topic")
 			if attr != nil {
 				attr.Value = to
+				rewrites = append(rewrites, fmt.Sprintf("task topic: '%v' -> '%v' in %v", from, to, element.SelectAttrValue("id", "")))
 			}
 		}
 	}
-	return doc.WriteToString()
+	result, err = doc.WriteToString()
+	return result, rewrites, err
 }
 
 func SetProcessId(xml string, id string) (result string, err error) {
+	result, _, err = setProcessId(xml, id)
+	return result, err
+}
+
+// getProcessDefinitionKey returns the camunda process definition key of the (first) process of a deployment
+// further processes of the deployment get the suffix "_<index>"
+func getProcessDefinitionKey(deploymentId string) string {
+	return "deplid_" + strings.NewReplacer("-", "_", ":", "_", "#", "_").Replace(deploymentId)
+}
+
+// isProcessDefinitionKeyOf checks if the process definition key was set by setProcessId with the key of the deployment
+// ("key" for the first process, "key_<index>" for further processes)
+func isProcessDefinitionKeyOf(processDefinitionKey string, deploymentKey string) bool {
+	if processDefinitionKey == deploymentKey {
+		return true
+	}
+	index, found := strings.CutPrefix(processDefinitionKey, deploymentKey+"_")
+	if !found || index == "" {
+		return false
+	}
+	for _, c := range index {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func setProcessId(xml string, id string) (result string, rewrites []string, err error) {
 	defer func() {
 		if r := recover(); r != nil && err == nil {
 			log.Printf("%s: %s", r, debug.Stack())
@@ -272,18 +513,21 @@ func SetProcessId(xml string, id string) (result string, err error) {
 	doc := etree.NewDocument()
 	err = doc.ReadFromString(xml)
 	if err != nil {
-		return result, err
+		return result, rewrites, err
 	}
-	normalizedId := "deplid_" + strings.NewReplacer("-", "_", ":", "_", "#", "_").Replace(id)
+	normalizedId := getProcessDefinitionKey(id)
 	for i, element := range doc.FindElements("//bpmn:process") {
 		attr := element.SelectAttr("id")
 		if attr != nil {
+			oldValue := attr.Value
 			if i > 0 {
 				attr.Value = normalizedId + "_" + strconv.Itoa(i)
 			} else {
 				attr.Value = normalizedId
 			}
+			rewrites = append(rewrites, fmt.Sprintf("process id: '%v' -> '%v'", oldValue, attr.Value))
 		}
 	}
-	return doc.WriteToString()
+	result, err = doc.WriteToString()
+	return result, rewrites, err
 }
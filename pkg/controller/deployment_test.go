@@ -0,0 +1,140 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+)
+
+func TestDryRunDeployment(t *testing.T) {
+	ctrl := &Controller{
+		config: configuration.Config{
+			TaskTopicReplace: map[string]string{"pessimistic": "optimistic"},
+			KnownTaskTopics:  []string{"optimistic"},
+		},
+		metadata: metadata.VoidStorage{},
+	}
+	deployment := model.FogDeploymentMessage{Deployment: deploymentmodel.Deployment{
+		Id:      "dry-run",
+		Diagram: deploymentmodel.Diagram{XmlDeployed: validationTestBpmn},
+	}}
+
+	t.Run("valid", func(t *testing.T) {
+		result := ctrl.DryRunDeployment(deployment)
+		if len(result.ValidationErrors) != 0 {
+			t.Error(result.ValidationErrors)
+		}
+		if result.DeploymentId != "dry-run" {
+			t.Error(result.DeploymentId)
+		}
+		if !strings.Contains(result.Xml, `id="deplid_dry_run"`) || !strings.Contains(result.Xml, `camunda:topic="optimistic"`) {
+			t.Error(result.Xml)
+		}
+		if len(result.Rewrites) != 2 {
+			t.Error(result.Rewrites)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := deployment
+		invalid.Diagram.XmlDeployed = strings.Replace(validationTestBpmn, `isExecutable="true"`, `isExecutable="false"`, 1)
+		result := ctrl.DryRunDeployment(invalid)
+		if len(result.ValidationErrors) != 1 || result.ValidationErrors[0].Rule != model.ValidationRuleExecutable {
+			t.Error(result.ValidationErrors)
+		}
+		if result.Xml == "" {
+			t.Error("missing xml")
+		}
+	})
+}
+
+func TestFindDeploymentWithHash(t *testing.T) {
+	ctrl := newTestController(t, configuration.Config{}, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/engine-rest/deployment/c1" {
+			json.NewEncoder(writer).Encode(camundamodel.Deployment{Id: "c1"})
+			return
+		}
+		http.NotFound(writer, request)
+	}))
+
+	deployment := model.FogDeploymentMessage{Deployment: deploymentmodel.Deployment{
+		Id:      "d1",
+		Diagram: deploymentmodel.Diagram{XmlDeployed: validationTestBpmn},
+	}}
+	hash, err := getDeploymentHash(deployment)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	changed := deployment
+	changed.Name = "changed"
+	changedHash, err := getDeploymentHash(changed)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if hash == changedHash {
+		t.Error("expected different hash for changed deployment")
+	}
+
+	for _, md := range []metadata.Metadata{
+		{CamundaDeploymentId: "c1", DeploymentModel: deployment, ContentHash: hash},
+		{CamundaDeploymentId: "c2", DeploymentModel: changed, ContentHash: changedHash}, //unknown to camunda
+	} {
+		err = ctrl.metadata.Store(md)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	md, found, err := ctrl.findDeploymentWithHash("d1", hash)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !found || md.CamundaDeploymentId != "c1" {
+		t.Error(found, md.CamundaDeploymentId)
+	}
+
+	_, found, err = ctrl.findDeploymentWithHash("d1", changedHash)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if found {
+		t.Error("deployment unknown to camunda should not be found")
+	}
+
+	_, found, err = ctrl.findDeploymentWithHash("d2", hash)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if found {
+		t.Error("unexpected match for other deployment id")
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// checkDeploymentDrift compares the camunda deployments with the metadata storage, publishes a drift report
+// and repairs the drift as configured by DeploymentDriftRepair
+// deployments must contain all camunda deployments, including deployments excluded by sync_include and sync_exclude
+// returns the metadata of deployments known to camunda
+func (this *Controller) checkDeploymentDrift(deployments []camundamodel.Deployment) (known []metadata.Metadata, err error) {
+	camundaDeploymentIds := []string{}
+	for _, deployment := range deployments {
+		camundaDeploymentIds = append(camundaDeploymentIds, deployment.Id)
+	}
+	if this.metadata.IsPlaceholder() {
+		return this.metadata.EnsureKnownDeployments(camundaDeploymentIds)
+	}
+	report, known, err := this.repairDeploymentDrift(camundaDeploymentIds)
+	if err != nil {
+		return known, err
+	}
+	err = this.fullSync.SendDeploymentDriftReport(report)
+	if err != nil {
+		log.Println("WARNING: unable to send deployment drift report", err)
+	}
+	return known, nil
+}
+
+// repairDeploymentDrift repairs the drift as configured by DeploymentDriftRepair
+// returns the drift report and the metadata of deployments known to camunda
+func (this *Controller) repairDeploymentDrift(camundaDeploymentIds []string) (report model.DeploymentDriftReport, known []metadata.Metadata, err error) {
+	stored, err := this.metadata.List()
+	if err != nil {
+		return report, known, err
+	}
+	report, known, orphaned := this.getDeploymentDriftReport(camundaDeploymentIds, stored)
+
+	switch this.config.DeploymentDriftRepair {
+	case configuration.DriftRepairRemove:
+		for _, md := range orphaned {
+			err = this.removeDeploymentMetadata(md.CamundaDeploymentId)
+			if err != nil {
+				report.Errors = append(report.Errors, err.Error())
+			} else {
+				report.Repaired = append(report.Repaired, "removed metadata of "+md.CamundaDeploymentId)
+			}
+		}
+	case configuration.DriftRepairRedeploy:
+		redeploy := orphaned
+		for _, mismatch := range report.XmlMismatch {
+			index := slices.IndexFunc(known, func(md metadata.Metadata) bool {
+				return md.CamundaDeploymentId == mismatch.CamundaDeploymentId
+			})
+			if index >= 0 {
+				redeploy = append(redeploy, known[index])
+				known = slices.Delete(known, index, index+1)
+			}
+		}
+		for _, md := range redeploy {
+			id, err := this.redeploy(md)
+			if err != nil {
+				report.Errors = append(report.Errors, err.Error())
+			} else {
+				report.Repaired = append(report.Repaired, "redeployed "+md.CamundaDeploymentId+" as "+id)
+			}
+		}
+	}
+	return report, known, nil
+}
+
+// getDeploymentDriftReport returns the report, the metadata of deployments known to camunda and the metadata of deployments missing in camunda
+func (this *Controller) getDeploymentDriftReport(camundaDeploymentIds []string, stored []metadata.Metadata) (report model.DeploymentDriftReport, known []metadata.Metadata, orphaned []metadata.Metadata) {
+	report = model.DeploymentDriftReport{
+		Time:              time.Now(),
+		MissingMetadata:   []string{},
+		MissingDeployment: []string{},
+		XmlMismatch:       []model.DeploymentXmlDrift{},
+		Repaired:          []string{},
+		Errors:            []string{},
+	}
+	inCamunda := map[string]bool{}
+	for _, id := range camundaDeploymentIds {
+		inCamunda[id] = true
+	}
+	inMetadata := map[string]bool{}
+	for _, md := range stored {
+		inMetadata[md.CamundaDeploymentId] = true
+		if inCamunda[md.CamundaDeploymentId] {
+			known = append(known, md)
+		} else {
+			orphaned = append(orphaned, md)
+			report.MissingDeployment = append(report.MissingDeployment, md.CamundaDeploymentId)
+		}
+	}
+	for _, id := range camundaDeploymentIds {
+		//deployments excluded by the sync filters are not reported
+		if !inMetadata[id] && this.isSyncedDeployment(id) {
+			report.MissingMetadata = append(report.MissingMetadata, id)
+		}
+	}
+	for _, md := range known {
+		drift, err := this.getDeploymentXmlDrift(md)
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			continue
+		}
+		if drift != nil {
+			report.XmlMismatch = append(report.XmlMismatch, *drift)
+		}
+	}
+	return report, known, orphaned
+}
+
+// returns nil if the xml deployed in camunda matches the xml stored on deployment
+func (this *Controller) getDeploymentXmlDrift(md metadata.Metadata) (drift *model.DeploymentXmlDrift, err error) {
+	if md.XmlHash == "" {
+		//deployed before the xml hash was stored; nothing to compare
+		return nil, nil
+	}
+	definitions, err := this.camunda.GetDefinitionByDeploymentVid(md.CamundaDeploymentId, UserId)
+	if err != nil {
+		return nil, err
+	}
+	if len(definitions) == 0 {
+		return nil, fmt.Errorf("no definition for deployment '%v' found", md.CamundaDeploymentId)
+	}
+	xml, err := this.camunda.GetProcessDefinitionXml(definitions[0].Id, UserId)
+	if err != nil {
+		return nil, err
+	}
+	expected := md.XmlHash
+	actual := hashXml(xml)
+	if expected == actual {
+		return nil, nil
+	}
+	return &model.DeploymentXmlDrift{
+		CamundaDeploymentId: md.CamundaDeploymentId,
+		DeploymentId:        md.DeploymentModel.Id,
+		ProcessDefinitionId: definitions[0].Id,
+		ExpectedHash:        expected,
+		ActualHash:          actual,
+	}, nil
+}
+
+func (this *Controller) redeploy(md metadata.Metadata) (id string, err error) {
+	hash, err := getDeploymentHash(md.DeploymentModel)
+	if err != nil {
+		return id, err
+	}
+	created, err := this.createDeployment(md.DeploymentModel, hash)
+	id = created.CamundaDeploymentId
+	if err != nil {
+		return id, err
+	}
+	this.sendLabelFilteredDeploymentStates(id)
+	if this.isSyncedDeployment(id) {
+		err = this.fullSync.SendDeploymentMetadata(created)
+		if err != nil {
+			log.Println("WARNING: unable to send metadata of redeployed deployment", id, err)
+		}
+	}
+	this.ensureKeepAlive(created)
+	err = this.camunda.RemoveProcess(md.CamundaDeploymentId, UserId)
+	if err != nil {
+		return id, err
+	}
+	return id, this.removeDeploymentMetadata(md.CamundaDeploymentId)
+}
+
+func (this *Controller) removeDeploymentMetadata(camundaDeploymentId string) error {
+	err := this.removeMetadata(camundaDeploymentId)
+	if err != nil {
+		return err
+	}
+	this.invalidateKeepAlive(camundaDeploymentId)
+	return this.RemoveConditionalEventOperators(camundaDeploymentId)
+}
+
+func hashXml(xml string) string {
+	hash := sha256.Sum256([]byte(strings.TrimSpace(xml)))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/events/repo"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+)
+
+func TestDeploymentDriftReport(t *testing.T) {
+	config := configuration.Config{}
+	ctrl := &Controller{config: config, metadata: metadata.VoidStorage{}}
+
+	newMetadata := func(camundaId string, id string) metadata.Metadata {
+		md := metadata.Metadata{
+			CamundaDeploymentId: camundaId,
+			DeploymentModel: model.FogDeploymentMessage{Deployment: deploymentmodel.Deployment{
+				Id:      id,
+				Diagram: deploymentmodel.Diagram{XmlDeployed: validationTestBpmn},
+			}},
+		}
+		md.XmlHash = hashXml(ctrl.prepareDeployment(md.DeploymentModel).Xml)
+		return md
+	}
+	unchanged := newMetadata("c1", "d1")
+	changed := newMetadata("c2", "d2")
+	orphaned := newMetadata("c3", "d3")
+	legacy := newMetadata("c5", "d5")
+	legacy.XmlHash = ""
+
+	deployedXml := map[string]string{}
+	for _, md := range []metadata.Metadata{unchanged, changed, legacy} {
+		deployedXml[md.CamundaDeploymentId] = ctrl.prepareDeployment(md.DeploymentModel).Xml
+	}
+	deployedXml["c2"] = strings.Replace(deployedXml["c2"], `name="msg"`, `name="changed"`, 1)
+	deployedXml["c5"] = strings.Replace(deployedXml["c5"], `name="msg"`, `name="changed"`, 1)
+
+	//changed config must not be reported as drift
+	ctrl.config.TaskTopicReplace = map[string]string{"pessimistic": "optimistic"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/engine-rest/process-definition" {
+			id := request.URL.Query().Get("deploymentId")
+			json.NewEncoder(writer).Encode(camundamodel.ProcessDefinitions{{Id: "def_" + id, DeploymentId: id}})
+			return
+		}
+		if strings.HasSuffix(request.URL.Path, "/xml") {
+			id := strings.TrimPrefix(strings.TrimSuffix(request.URL.Path, "/xml"), "/engine-rest/process-definition/def_")
+			json.NewEncoder(writer).Encode(camundamodel.ProcessDefinitionXml{Id: "def_" + id, Bpmn20Xml: deployedXml[id]})
+			return
+		}
+		http.NotFound(writer, request)
+	}))
+	defer server.Close()
+	ctrl.camunda = camunda.New(config, shards.Shards(server.URL))
+
+	report, known, orphanedResult := ctrl.getDeploymentDriftReport([]string{"c1", "c2", "c4", "c5"}, []metadata.Metadata{unchanged, changed, orphaned, legacy})
+	if len(report.Errors) > 0 {
+		t.Error(report.Errors)
+	}
+	if !reflect.DeepEqual(report.MissingMetadata, []string{"c4"}) {
+		t.Error(report.MissingMetadata)
+	}
+	if !reflect.DeepEqual(report.MissingDeployment, []string{"c3"}) {
+		t.Error(report.MissingDeployment)
+	}
+	if len(report.XmlMismatch) != 1 || report.XmlMismatch[0].CamundaDeploymentId != "c2" || report.XmlMismatch[0].DeploymentId != "d2" || report.XmlMismatch[0].ProcessDefinitionId != "def_c2" {
+		t.Errorf("%#v", report.XmlMismatch)
+	}
+	if len(known) != 3 || known[0].CamundaDeploymentId != "c1" || known[1].CamundaDeploymentId != "c2" || known[2].CamundaDeploymentId != "c5" {
+		t.Error(known)
+	}
+	if len(orphanedResult) != 1 || orphanedResult[0].CamundaDeploymentId != "c3" {
+		t.Error(orphanedResult)
+	}
+}
+
+func TestDeploymentDriftRepairWithSyncFilter(t *testing.T) {
+	config := configuration.Config{
+		DeploymentDriftRepair: configuration.DriftRepairRemove,
+		SyncExclude:           []configuration.SyncFilter{{Name: "test-*"}},
+	}
+	stored := []metadata.Metadata{}
+	for _, camundaId := range []string{"excluded", "orphaned"} {
+		stored = append(stored, metadata.Metadata{
+			CamundaDeploymentId: camundaId,
+			DeploymentModel: model.FogDeploymentMessage{Deployment: deploymentmodel.Deployment{
+				Id:      "d_" + camundaId,
+				Diagram: deploymentmodel.Diagram{XmlDeployed: validationTestBpmn},
+			}},
+		})
+	}
+	deployedXml := ""
+	ctrl := newTestController(t, config, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/engine-rest/process-definition" {
+			id := request.URL.Query().Get("deploymentId")
+			json.NewEncoder(writer).Encode(camundamodel.ProcessDefinitions{{Id: "def_" + id, DeploymentId: id}})
+			return
+		}
+		if strings.HasSuffix(request.URL.Path, "/xml") {
+			json.NewEncoder(writer).Encode(camundamodel.ProcessDefinitionXml{Bpmn20Xml: deployedXml})
+			return
+		}
+		http.NotFound(writer, request)
+	}), stored...)
+	deployedXml = ctrl.prepareDeployment(stored[0].DeploymentModel).Xml
+	events, err := repo.New(context.Background(), ctrl.config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ctrl.events = events
+
+	deployments := camundamodel.Deployments{{Id: "excluded", Name: "test-heating"}}
+	if ctrl.isSyncedCamundaDeployment(deployments[0]) {
+		t.Error("expected excluded deployment")
+	}
+
+	report, known, err := ctrl.repairDeploymentDrift([]string{"excluded"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(report.MissingDeployment, []string{"orphaned"}) || len(report.Errors) > 0 {
+		t.Errorf("%#v", report)
+	}
+	if len(known) != 1 || known[0].CamundaDeploymentId != "excluded" {
+		t.Error(known)
+	}
+	if _, err = ctrl.metadata.Read("excluded"); err != nil {
+		t.Error("metadata of excluded deployment removed", err)
+	}
+	if _, err = ctrl.metadata.Read("orphaned"); err == nil {
+		t.Error("expected removed metadata of orphaned deployment")
+	}
+}
@@ -18,24 +18,23 @@ package controller
 
 import (
 	eventmodel "github.com/SENERGY-Platform/event-worker/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
 	"log"
 	"runtime/debug"
 )
 
+// UpdateDeploymentEvents handles the event descriptions update command; the metadata is sent with the command source
 func (this *Controller) UpdateDeploymentEvents(camundaDeploymentId string, descriptions []eventmodel.EventDesc, deviceMapping map[string]string, serviceMapping map[string]string) error {
 	if this.metadata.IsPlaceholder() {
 		return nil
 	}
-	m, err := this.metadata.Read(camundaDeploymentId)
-	if err != nil {
-		log.Println("ERROR: unable to update events", err)
-		debug.PrintStack()
-		return nil
-	}
-	m.DeploymentModel.EventDescriptions = descriptions
-	m.DeploymentModel.DeviceIdToLocalId = deviceMapping
-	m.DeploymentModel.ServiceIdToLocalId = serviceMapping
-	err = this.metadata.Store(m)
+	var m metadata.Metadata
+	err := this.updateMetadata(camundaDeploymentId, func(md *metadata.Metadata) {
+		md.DeploymentModel.EventDescriptions = descriptions
+		md.DeploymentModel.DeviceIdToLocalId = deviceMapping
+		md.DeploymentModel.ServiceIdToLocalId = serviceMapping
+		m = *md
+	})
 	if err != nil {
 		log.Println("ERROR: unable to update events", err)
 		debug.PrintStack()
@@ -49,5 +48,5 @@ func (this *Controller) UpdateDeploymentEvents(camundaDeploymentId string, descr
 	if err != nil {
 		return err
 	}
-	return this.backend.SendDeploymentMetadata(m)
+	return this.command.SendDeploymentMetadata(m)
 }
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// {"id_":"6b84bb07-750c-11eb-b54c-0242ac110006","rev_":1,"worker_id_":null,"topic_name_":"optimistic","retries_":null,"error_msg_":null,"error_details_id_":null,"lock_exp_time_":null,"suspension_state_":1,"execution_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_def_id_":"ExampleId:1:686e7a53-750c-11eb-b54c-0242ac110006","proc_def_key_":"ExampleId","act_id_":"Task_1","act_inst_id_":"Task_1:6b84bb06-750c-11eb-b54c-0242ac110006","tenant_id_":"user","priority_":0,"last_failure_log_id_":null,"business_key_":null}
+type ExternalTaskInPg struct {
+	Id                   string  `json:"id_"`
+	WorkerId             string  `json:"worker_id_"`
+	TopicName            string  `json:"topic_name_"`
+	Retries              *int64  `json:"retries_"`
+	ErrorMessage         string  `json:"error_msg_"`
+	ErrorDetailsId       *string `json:"error_details_id_"`
+	LockExpirationTime   string  `json:"lock_exp_time_"`
+	SuspensionState      int     `json:"suspension_state_"` //1: active, 2: suspended
+	ExecutionId          string  `json:"execution_id_"`
+	ProcessInstanceId    string  `json:"proc_inst_id_"`
+	ProcessDefinitionId  string  `json:"proc_def_id_"`
+	ProcessDefinitionKey string  `json:"proc_def_key_"`
+	ActivityId           string  `json:"act_id_"`
+	ActivityInstanceId   string  `json:"act_inst_id_"`
+	TenantId             string  `json:"tenant_id_"`
+	Priority             float64 `json:"priority_"`
+	BusinessKey          string  `json:"business_key_"`
+}
+
+func (this *Controller) NotifyExternalTaskUpdate(extra string) {
+	element := ExternalTaskInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal external task in NotifyExternalTaskUpdate(): ", err)
+		return
+	}
+	this.externalTaskSeen(element.Id, time.Now())
+	if !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	task := camundamodel.ExternalTask{
+		Id:                   element.Id,
+		TopicName:            element.TopicName,
+		WorkerId:             element.WorkerId,
+		LockExpirationTime:   element.LockExpirationTime,
+		ActivityId:           element.ActivityId,
+		ActivityInstanceId:   element.ActivityInstanceId,
+		ExecutionId:          element.ExecutionId,
+		ProcessInstanceId:    element.ProcessInstanceId,
+		ProcessDefinitionId:  element.ProcessDefinitionId,
+		ProcessDefinitionKey: element.ProcessDefinitionKey,
+		BusinessKey:          element.BusinessKey,
+		Retries:              element.Retries,
+		ErrorMessage:         element.ErrorMessage,
+		Suspended:            element.SuspensionState == 2,
+		Priority:             element.Priority,
+		TenantId:             element.TenantId,
+	}
+	if element.ErrorDetailsId != nil {
+		task.ErrorDetails, err = this.camunda.GetExternalTaskErrorDetails(element.Id, UserId)
+		if err != nil {
+			log.Println("WARNING: unable to get external task error details in NotifyExternalTaskUpdate(): ", err)
+		}
+	}
+	err = this.backend.SendExternalTaskUpdate(task)
+	if err != nil {
+		log.Println("ERROR: unable to send external task update in NotifyExternalTaskUpdate(): ", err)
+		return
+	}
+}
+
+func (this *Controller) NotifyExternalTaskDelete(extra string) {
+	element := ExternalTaskInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal external task in NotifyExternalTaskDelete(): ", err)
+		return
+	}
+	this.externalTaskFirstSeenMux.Lock()
+	delete(this.externalTaskFirstSeen, element.Id)
+	this.externalTaskFirstSeenMux.Unlock()
+	if !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	err = this.backend.SendExternalTaskDelete(element.Id)
+	if err != nil {
+		log.Println("ERROR: unable to send external task delete in NotifyExternalTaskDelete(): ", err)
+		return
+	}
+}
+
+func (this *Controller) SendCurrentExternalTasks() error {
+	if !this.config.SyncExternalTasks {
+		return nil
+	}
+	tasks, err := this.camunda.GetExternalTaskList(UserId)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, task := range tasks {
+		if !this.isSyncedDefinition(task.ProcessDefinitionId) {
+			continue
+		}
+		ids = append(ids, task.Id)
+		err = this.fullSync.SendExternalTaskUpdate(task)
+		if err != nil {
+			return err
+		}
+	}
+	return this.fullSync.SendExternalTaskKnownIds(ids)
+}
+
+// externalTaskSeen stores the first time the client has seen the task; camunda does not provide a creation time
+func (this *Controller) externalTaskSeen(id string, now time.Time) time.Time {
+	this.externalTaskFirstSeenMux.Lock()
+	defer this.externalTaskFirstSeenMux.Unlock()
+	if this.externalTaskFirstSeen == nil {
+		this.externalTaskFirstSeen = map[string]time.Time{}
+	}
+	if first, ok := this.externalTaskFirstSeen[id]; ok {
+		return first
+	}
+	this.externalTaskFirstSeen[id] = now
+	return now
+}
+
+func (this *Controller) startExternalTaskSummary(ctx context.Context) {
+	if !this.config.SyncExternalTasks || this.config.ExternalTaskSummaryInterval == "" || this.config.ExternalTaskSummaryInterval == "-" {
+		return
+	}
+	interval, err := time.ParseDuration(this.config.ExternalTaskSummaryInterval)
+	if err != nil {
+		log.Println("WARNING: unable to parse external task summary interval", this.config.ExternalTaskSummaryInterval, err)
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case t := <-ticker.C:
+				tasks, err := this.camunda.GetExternalTaskList(UserId)
+				if err != nil {
+					log.Println("ERROR: unable to load external tasks for summary", err)
+					continue
+				}
+				err = this.backend.SendExternalTaskSummary(this.summarizeExternalTasks(tasks, t))
+				if err != nil {
+					log.Println("ERROR: unable to send external task summary", err)
+				}
+			}
+		}
+	}()
+}
+
+func (this *Controller) summarizeExternalTasks(tasks camundamodel.ExternalTasks, now time.Time) (result model.ExternalTaskSummary) {
+	result = model.ExternalTaskSummary{Time: now, Topics: []model.ExternalTaskTopicSummary{}}
+	topics := map[string]*model.ExternalTaskTopicSummary{}
+	current := map[string]bool{}
+	for _, task := range tasks {
+		current[task.Id] = true
+		summary, ok := topics[task.TopicName]
+		if !ok {
+			summary = &model.ExternalTaskTopicSummary{Topic: task.TopicName, ActiveWorkers: []string{}}
+			topics[task.TopicName] = summary
+		}
+		firstSeen := this.externalTaskSeen(task.Id, now)
+		switch {
+		case task.Retries != nil && *task.Retries <= 0:
+			summary.Failed++
+		case isLocked(task, now):
+			summary.Locked++
+			if task.WorkerId != "" && !slices.Contains(summary.ActiveWorkers, task.WorkerId) {
+				summary.ActiveWorkers = append(summary.ActiveWorkers, task.WorkerId)
+			}
+		default:
+			summary.Waiting++
+			if age := now.Sub(firstSeen).Milliseconds(); age > summary.OldestWaitingAgeMs {
+				summary.OldestWaitingAgeMs = age
+			}
+		}
+	}
+	//forget tasks missed by delete notifications
+	this.externalTaskFirstSeenMux.Lock()
+	for id := range this.externalTaskFirstSeen {
+		if !current[id] {
+			delete(this.externalTaskFirstSeen, id)
+		}
+	}
+	this.externalTaskFirstSeenMux.Unlock()
+
+	for _, summary := range topics {
+		slices.Sort(summary.ActiveWorkers)
+		result.Topics = append(result.Topics, *summary)
+	}
+	slices.SortFunc(result.Topics, func(a, b model.ExternalTaskTopicSummary) int {
+		if a.Topic < b.Topic {
+			return -1
+		}
+		if a.Topic > b.Topic {
+			return 1
+		}
+		return 0
+	})
+	return result
+}
+
+func isLocked(task camundamodel.ExternalTask, now time.Time) bool {
+	if task.LockExpirationTime == "" {
+		return false
+	}
+	expiration, err := time.Parse(camundaDateFormat, task.LockExpirationTime)
+	if err != nil {
+		return task.WorkerId != ""
+	}
+	return expiration.After(now)
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func TestSummarizeExternalTasks(t *testing.T) {
+	ctrl := &Controller{}
+	now := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+	locked := now.Add(time.Minute).Format(camundaDateFormat)
+	expired := now.Add(-time.Minute).Format(camundaDateFormat)
+	noRetries := int64(0)
+
+	ctrl.externalTaskSeen("t1", now.Add(-10*time.Minute))
+	ctrl.externalTaskSeen("gone", now.Add(-time.Hour))
+
+	summary := ctrl.summarizeExternalTasks(camundamodel.ExternalTasks{
+		{Id: "t1", TopicName: "optimistic"},
+		{Id: "t2", TopicName: "optimistic", WorkerId: "w1", LockExpirationTime: expired},
+		{Id: "t3", TopicName: "optimistic", WorkerId: "w2", LockExpirationTime: locked},
+		{Id: "t4", TopicName: "optimistic", WorkerId: "w1", LockExpirationTime: locked},
+		{Id: "t5", TopicName: "pessimistic", Retries: &noRetries},
+	}, now)
+
+	expected := []model.ExternalTaskTopicSummary{
+		{Topic: "optimistic", Waiting: 2, Locked: 2, OldestWaitingAgeMs: (10 * time.Minute).Milliseconds(), ActiveWorkers: []string{"w1", "w2"}},
+		{Topic: "pessimistic", Failed: 1, ActiveWorkers: []string{}},
+	}
+	if !reflect.DeepEqual(summary.Topics, expected) {
+		t.Errorf("%#v", summary.Topics)
+	}
+	if _, ok := ctrl.externalTaskFirstSeen["gone"]; ok {
+		t.Error("unknown task should be forgotten")
+	}
+}
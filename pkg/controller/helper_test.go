@@ -0,0 +1,56 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+)
+
+// newTestController returns a controller with a bolt metadata storage in t.TempDir() and the stored metadata
+// camunda requests are handled by the fake camunda handler; handler may be nil if the test sends no camunda requests
+// the controller has no backend, so tests must not reach the send paths
+func newTestController(t *testing.T, config configuration.Config, camundaHandler http.Handler, stored ...metadata.Metadata) *Controller {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	config.DeploymentMetadataStorage = t.TempDir() + "/test.db"
+	storage, err := metadata.NewStorage(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, md := range stored {
+		err = storage.Store(md)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctrl := &Controller{config: config, metadata: storage}
+	if camundaHandler != nil {
+		server := httptest.NewServer(camundaHandler)
+		t.Cleanup(server.Close)
+		ctrl.camunda = camunda.New(config, shards.Shards(server.URL))
+	}
+	return ctrl
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"log"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// instanceHierarchy describes the position of a process instance in a call-activity hierarchy
+type instanceHierarchy struct {
+	SuperProcessInstanceId string
+	RootProcessInstanceId  string
+	DeploymentId           string
+	RootDeploymentId       string
+}
+
+// getInstanceHierarchy resolves missing parent and root links of the instance and the deployments of the instance and its root
+// superProcessInstanceId and rootProcessInstanceId may be empty if unknown
+func (this *Controller) getInstanceHierarchy(instanceId string, definitionId string, superProcessInstanceId string, rootProcessInstanceId string) (result instanceHierarchy) {
+	result = instanceHierarchy{
+		SuperProcessInstanceId: superProcessInstanceId,
+		RootProcessInstanceId:  rootProcessInstanceId,
+		DeploymentId:           this.getDeploymentIdOfDefinition(definitionId),
+	}
+	if result.RootProcessInstanceId == "" || (result.SuperProcessInstanceId == "" && result.RootProcessInstanceId != instanceId) {
+		links, err := this.getInstanceLinks(instanceId)
+		if err != nil {
+			log.Println("WARNING: unable to get historic process instance for call hierarchy", instanceId, err)
+		} else {
+			result.SuperProcessInstanceId = links.SuperProcessInstanceId
+			result.RootProcessInstanceId = links.RootProcessInstanceId
+		}
+	}
+	if result.RootProcessInstanceId == "" {
+		result.RootProcessInstanceId = instanceId
+	}
+	if result.RootProcessInstanceId == instanceId {
+		result.RootDeploymentId = result.DeploymentId
+		return result
+	}
+	root, err := this.getInstanceLinks(result.RootProcessInstanceId)
+	if err != nil {
+		log.Println("WARNING: unable to get root process instance for call hierarchy", result.RootProcessInstanceId, err)
+		return result
+	}
+	result.RootDeploymentId = this.getDeploymentIdOfDefinition(root.ProcessDefinitionId)
+	return result
+}
+
+func (this instanceHierarchy) applyToInstance(instance *camundamodel.ProcessInstance) {
+	instance.SuperProcessInstanceId = this.SuperProcessInstanceId
+	instance.RootProcessInstanceId = this.RootProcessInstanceId
+	instance.DeploymentId = this.DeploymentId
+	instance.RootDeploymentId = this.RootDeploymentId
+}
+
+func (this instanceHierarchy) applyToHistory(history *camundamodel.HistoricProcessInstance) {
+	history.SuperProcessInstanceId = this.SuperProcessInstanceId
+	history.RootProcessInstanceId = this.RootProcessInstanceId
+	history.DeploymentId = this.DeploymentId
+	history.RootDeploymentId = this.RootDeploymentId
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/service-commons/pkg/cache"
+)
+
+func TestGetInstanceHierarchy(t *testing.T) {
+	histories := map[string]camundamodel.HistoricProcessInstance{
+		"root": {Id: "root", ProcessDefinitionId: "main:1", RootProcessInstanceId: "root", BusinessKey: "bk"},
+		"sub":  {Id: "sub", ProcessDefinitionId: "called:1", SuperProcessInstanceId: "root", RootProcessInstanceId: "root"},
+		"leaf": {Id: "leaf", ProcessDefinitionId: "called:1", SuperProcessInstanceId: "sub", RootProcessInstanceId: "root"},
+	}
+	definitionRequests := 0
+	historyRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch {
+		case strings.HasPrefix(request.URL.Path, "/engine-rest/history/process-instance/"):
+			historyRequests++
+			history, ok := histories[strings.TrimPrefix(request.URL.Path, "/engine-rest/history/process-instance/")]
+			if !ok {
+				http.NotFound(writer, request)
+				return
+			}
+			json.NewEncoder(writer).Encode(history)
+		case strings.HasPrefix(request.URL.Path, "/engine-rest/process-definition/"):
+			definitionRequests++
+			id := strings.TrimPrefix(request.URL.Path, "/engine-rest/process-definition/")
+			json.NewEncoder(writer).Encode(camundamodel.ProcessDefinition{Id: id, DeploymentId: "depl_" + strings.Split(id, ":")[0]})
+		default:
+			http.NotFound(writer, request)
+		}
+	}))
+	defer server.Close()
+	config := configuration.Config{}
+	instanceCache, err := cache.New(cache.Config{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ctrl := &Controller{config: config, camunda: camunda.New(config, shards.Shards(server.URL)), instanceCache: instanceCache}
+
+	t.Run("root", func(t *testing.T) {
+		result := ctrl.getInstanceHierarchy("root", "main:1", "", "root")
+		expected := instanceHierarchy{RootProcessInstanceId: "root", DeploymentId: "depl_main", RootDeploymentId: "depl_main"}
+		if result != expected {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("unknown links", func(t *testing.T) {
+		result := ctrl.getInstanceHierarchy("leaf", "called:1", "", "")
+		expected := instanceHierarchy{SuperProcessInstanceId: "sub", RootProcessInstanceId: "root", DeploymentId: "depl_called", RootDeploymentId: "depl_main"}
+		if result != expected {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("known links", func(t *testing.T) {
+		result := ctrl.getInstanceHierarchy("sub", "called:1", "root", "root")
+		expected := instanceHierarchy{SuperProcessInstanceId: "root", RootProcessInstanceId: "root", DeploymentId: "depl_called", RootDeploymentId: "depl_main"}
+		if result != expected {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	if definitionRequests != 2 {
+		t.Error("expected cached definition lookups", definitionRequests)
+	}
+	if historyRequests != 2 {
+		t.Error("expected cached instance lookups", historyRequests)
+	}
+
+	ctrl.getInstanceHierarchy("leaf", "called:1", "", "")
+	if historyRequests != 2 {
+		t.Error("expected cached instance lookups", historyRequests)
+	}
+	ctrl.invalidateInstanceLinks("leaf")
+	ctrl.getInstanceHierarchy("leaf", "called:1", "", "")
+	if historyRequests != 3 {
+		t.Error("expected instance lookup after invalidation", historyRequests)
+	}
+
+	links, err := ctrl.getInstanceLinks("root")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if links.BusinessKey != "bk" || historyRequests != 3 {
+		t.Error("expected cached business key", links, historyRequests)
+	}
+}
+
+func TestGetSuperProcessInstanceId(t *testing.T) {
+	instanceCache, err := cache.New(cache.Config{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ctrl := &Controller{instanceCache: instanceCache}
+	callActivity := "call_activity"
+	unknown := "unknown"
+
+	ctrl.cacheExecutionInstance("root", "root")
+	ctrl.cacheExecutionInstance(callActivity, "root")
+
+	if result := ctrl.getSuperProcessInstanceId(ProcessInstanceInPg{Id: "root", ProcessInstance: "root"}); result != "" {
+		t.Error(result)
+	}
+	if result := ctrl.getSuperProcessInstanceId(ProcessInstanceInPg{Id: "sub", ProcessInstance: "sub", SuperExecution: &callActivity}); result != "root" {
+		t.Error(result)
+	}
+	if result := ctrl.getSuperProcessInstanceId(ProcessInstanceInPg{Id: "sub2", ProcessInstance: "sub2", SuperExecution: &unknown}); result != "" {
+		t.Error(result)
+	}
+	ctrl.invalidateExecutionInstance(callActivity)
+	if result := ctrl.getSuperProcessInstanceId(ProcessInstanceInPg{Id: "sub", ProcessInstance: "sub", SuperExecution: &callActivity}); result != "" {
+		t.Error("expected invalidated execution", result)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
 	"log"
+	"time"
 )
 
 func (this *Controller) DeleteProcessInstanceHistory(id string) error {
@@ -30,6 +31,7 @@ func (this *Controller) DeleteProcessInstanceHistory(id string) error {
 type ProcessInstanceHistoryInPg struct {
 	Id                     string  `json:"id_"`
 	SuperProcessInstanceId string  `json:"super_process_instance_id_"`
+	RootProcessInstanceId  string  `json:"root_proc_inst_id_"`
 	SuperCaseInstanceId    string  `json:"super_case_instance_id_"`
 	CaseInstanceId         string  `json:"case_inst_id_"`
 	ProcessDefinitionKey   string  `json:"proc_def_key_"`
@@ -70,7 +72,7 @@ func (this *Controller) NotifyHistoryUpdate(extra string) {
 		State:                  element.State,
 	}
 
-	definition, err := this.camunda.GetProcessDefinition(element.ProcessDefinitionId, UserId)
+	definition, err := this.getProcessDefinition(element.ProcessDefinitionId)
 	if err != nil {
 		log.Println("WARNING: unable to get process definition in NotifyHistoryUpdate(): ", err)
 		err = nil
@@ -78,11 +80,20 @@ func (this *Controller) NotifyHistoryUpdate(extra string) {
 		history.ProcessDefinitionName = definition.Name
 		history.ProcessDefinitionVersion = float64(definition.Version)
 	}
+	this.getInstanceHierarchy(element.Id, element.ProcessDefinitionId, element.SuperProcessInstanceId, element.RootProcessInstanceId).applyToHistory(&history)
+	this.onKpiHistory(element, time.Now())
 
-	err = this.backend.SendProcessHistoryUpdate(history)
-	if err != nil {
-		log.Println("ERROR: unable to send history update in SendProcessHistoryUpdate(): ", err)
-		return
+	if this.isSyncedDefinition(element.ProcessDefinitionId) {
+		err = this.backend.SendProcessHistoryUpdate(history)
+		if err != nil {
+			//the local handling of the ended instance does not depend on the cloud connection
+			log.Println("ERROR: unable to send history update in SendProcessHistoryUpdate(): ", err)
+		}
+	}
+
+	if element.EndTime != "" && element.SuperProcessInstanceId == "" {
+		this.unwatchInstance(element.Id)
+		this.onInstanceEnded(element.ProcessDefinitionId)
 	}
 }
 
@@ -93,6 +104,10 @@ func (this *Controller) NotifyHistoryDelete(extra string) {
 		log.Println("ERROR: unable to unmarshal history in NotifyHistoryDelete(): ", err)
 		return
 	}
+	this.invalidateInstanceLinks(element.Id)
+	if !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
 	err = this.backend.SendProcessHistoryDelete(element.Id)
 	if err != nil {
 		log.Println("ERROR: unable to send history update in NotifyHistoryDelete(): ", err)
@@ -107,11 +122,15 @@ func (this *Controller) SendCurrentHistories() error {
 	}
 	ids := []string{}
 	for _, instance := range instances {
+		if !this.isSyncedDefinition(instance.ProcessDefinitionId) {
+			continue
+		}
 		ids = append(ids, instance.Id)
-		err = this.backend.SendProcessHistoryUpdate(instance)
+		this.getInstanceHierarchy(instance.Id, instance.ProcessDefinitionId, instance.SuperProcessInstanceId, instance.RootProcessInstanceId).applyToHistory(&instance)
+		err = this.fullSync.SendProcessHistoryUpdate(instance)
 		if err != nil {
 			return err
 		}
 	}
-	return this.backend.SendProcessHistoryKnownIds(ids)
+	return this.fullSync.SendProcessHistoryKnownIds(ids)
 }
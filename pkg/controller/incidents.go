@@ -51,17 +51,21 @@ func (this *Controller) NotifyIncident(extra string) {
 		log.Println("ERROR: unable to unmarshal process incident in NotifyIncident(): ", err)
 		return
 	}
+	this.onKpiIncident(element.ProcessDefinitionId)
+	if !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
 
-	def, err := this.camunda.GetProcessDefinition(element.ProcessDefinitionId, UserId)
+	def, err := this.getProcessDefinition(element.ProcessDefinitionId)
 	if err != nil {
 		log.Println("WARNING: unable to get process def in NotifyIncident(): ", err)
 		def = camundamodel.ProcessDefinition{Name: "unknown"}
 	}
 
-	instance, err := this.camunda.GetHistoricProcessInstance(element.ProcessInstanceId, UserId)
+	instance, err := this.getInstanceLinks(element.ProcessInstanceId)
 	if err != nil {
 		log.Println("WARNING: unable to get process instance in NotifyIncident(): ", err)
-		instance = camundamodel.HistoricProcessInstance{}
+		instance = instanceLinks{}
 	}
 
 	err = this.backend.SendIncident(camundamodel.Incident{
@@ -82,16 +86,19 @@ func (this *Controller) NotifyIncident(extra string) {
 }
 
 func (this *Controller) sendPgIncident(incident ProcessIncidentInPg) {
-	def, err := this.camunda.GetProcessDefinition(incident.ProcessDefinitionId, UserId)
+	if !this.isSyncedDefinition(incident.ProcessDefinitionId) {
+		return
+	}
+	def, err := this.getProcessDefinition(incident.ProcessDefinitionId)
 	if err != nil {
 		log.Println("WARNING: unable to get process def in NotifyIncident(): ", err)
 		def = camundamodel.ProcessDefinition{Name: "unknown"}
 	}
 
-	instance, err := this.camunda.GetHistoricProcessInstance(incident.ProcessInstanceId, UserId)
+	instance, err := this.getInstanceLinks(incident.ProcessInstanceId)
 	if err != nil {
 		log.Println("WARNING: unable to get process instance in NotifyIncident(): ", err)
-		instance = camundamodel.HistoricProcessInstance{}
+		instance = instanceLinks{}
 	}
 
 	err = this.backend.SendIncident(camundamodel.Incident{
@@ -19,7 +19,9 @@ package controller
 import (
 	"encoding/json"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/service-commons/pkg/cache"
 	"log"
+	"time"
 )
 
 func (this *Controller) DeleteProcessInstance(id string) error {
@@ -29,6 +31,9 @@ func (this *Controller) DeleteProcessInstance(id string) error {
 // {"id_":"6b84bb04-750c-11eb-b54c-0242ac110006","rev_":1,"root_proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","business_key_":null,"parent_id_":null,"proc_def_id_":"ExampleId:1:686e7a53-750c-11eb-b54c-0242ac110006","super_exec_":null,"super_case_exec_":null,"case_inst_id_":null,"act_id_":null,"act_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","is_active_":false,"is_concurrent_":false,"is_scope_":true,"is_event_scope_":false,"suspension_state_":1,"cached_ent_state_":0,"sequence_counter_":2,"tenant_id_":"user"}
 type ProcessInstanceInPg struct {
 	Id               string  `json:"id_"`
+	ProcessInstance  string  `json:"proc_inst_id_"`
+	RootInstance     string  `json:"root_proc_inst_id_"`
+	SuperExecution   *string `json:"super_exec_"` //set for instances of called sub-processes
 	DefinitionId     string  `json:"proc_def_id_"`
 	BusinessKey      string  `json:"business_key_"`
 	CaseInstanceId   string  `json:"case_inst_id_"`
@@ -45,8 +50,12 @@ func (this *Controller) NotifyInstanceUpdate(extra string) {
 		log.Println("ERROR: unable to unmarshal instance in NotifyInstanceUpdate(): ", err)
 		return
 	}
-	//forward only root instances
-	if isRootInstance(element) {
+	this.cacheExecutionInstance(element.Id, element.ProcessInstance)
+	if element.ProcessInstance != "" {
+		this.onInstanceActivity(element.ProcessInstance, element.DefinitionId, element.BusinessKey, isRootInstance(element), time.Now())
+	}
+	//forward only process instance executions; instances of called sub-processes are forwarded with their call hierarchy
+	if isRootInstance(element) && this.isSyncedDefinition(element.DefinitionId) {
 		instance := camundamodel.ProcessInstance{
 			Id:             element.Id,
 			DefinitionId:   element.DefinitionId,
@@ -56,6 +65,7 @@ func (this *Controller) NotifyInstanceUpdate(extra string) {
 			Suspended:      !element.Active,
 			TenantId:       element.TenantId,
 		}
+		this.getInstanceHierarchy(element.Id, element.DefinitionId, this.getSuperProcessInstanceId(element), element.RootInstance).applyToInstance(&instance)
 		err = this.backend.SendProcessInstanceUpdate(instance)
 		if err != nil {
 			log.Println("ERROR: unable to send process instance update in NotifyInstanceUpdate(): ", err)
@@ -71,16 +81,63 @@ func (this *Controller) NotifyInstanceDelete(extra string) {
 		log.Println("ERROR: unable to unmarshal process instance in NotifyInstanceDelete(): ", err)
 		return
 	}
+	this.invalidateExecutionInstance(element.Id)
 	//forward only root instances
 	if isRootInstance(element) {
-		err = this.backend.SendProcessInstanceDelete(element.Id)
-		if err != nil {
-			log.Println("ERROR: unable to send process instance delete in NotifyInstanceDelete(): ", err)
-			return
+		this.unwatchInstance(element.Id)
+		if this.isSyncedDefinition(element.DefinitionId) {
+			err = this.backend.SendProcessInstanceDelete(element.Id)
+			if err != nil {
+				//the local handling of the ended instance does not depend on the cloud connection
+				log.Println("ERROR: unable to send process instance delete in NotifyInstanceDelete(): ", err)
+			}
+		}
+		this.onInstanceEnded(element.DefinitionId)
+		if decision := this.startQueued(element.DefinitionId, element.BusinessKey); decision != nil {
+			err = this.backend.SendBusinessKeyDecision(*decision)
+			if err != nil {
+				log.Println("ERROR: unable to send business key decision in NotifyInstanceDelete(): ", err)
+			}
 		}
 	}
 }
 
+// getSuperProcessInstanceId resolves the super_exec_ of called sub-processes to the process instance of the call activity
+// returns "" if the instance is no called sub-process or the super execution is unknown; getInstanceHierarchy falls back to the history
+func (this *Controller) getSuperProcessInstanceId(pgInstance ProcessInstanceInPg) string {
+	if pgInstance.SuperExecution == nil || *pgInstance.SuperExecution == "" || this.instanceCache == nil {
+		return ""
+	}
+	result, err := cache.Get[string](this.instanceCache, getExecutionInstanceCacheKey(*pgInstance.SuperExecution), cache.NoValidation[string])
+	if err != nil {
+		return ""
+	}
+	return result
+}
+
+// cacheExecutionInstance remembers the process instance of every notified execution
+// call activity executions are notified before the instances of the called sub-processes
+func (this *Controller) cacheExecutionInstance(executionId string, processInstanceId string) {
+	if this.instanceCache == nil || executionId == "" || processInstanceId == "" {
+		return
+	}
+	err := this.instanceCache.Set(getExecutionInstanceCacheKey(executionId), processInstanceId, instanceLinksExpiration)
+	if err != nil {
+		log.Println("WARNING: unable to cache process instance of execution", executionId, err)
+	}
+}
+
+func (this *Controller) invalidateExecutionInstance(executionId string) {
+	if this.instanceCache == nil {
+		return
+	}
+	_ = this.instanceCache.Remove(getExecutionInstanceCacheKey(executionId)) //not found errors are expected
+}
+
+func getExecutionInstanceCacheKey(executionId string) string {
+	return "execution-instance:" + executionId
+}
+
 func isRootInstance(pgInstance ProcessInstanceInPg) bool {
 	return pgInstance.ParentInstanceId == nil
 }
@@ -90,13 +147,27 @@ func (this *Controller) SendCurrentInstances() error {
 	if err != nil {
 		return err
 	}
+	//the process instance api does not provide the call hierarchy
+	histories, err := this.camunda.GetProcessInstanceHistoryListUnfinished(UserId)
+	if err != nil {
+		return err
+	}
+	historyById := map[string]camundamodel.HistoricProcessInstance{}
+	for _, history := range histories {
+		historyById[history.Id] = history
+	}
 	ids := []string{}
 	for _, instance := range instances {
+		if !this.isSyncedDefinition(instance.DefinitionId) {
+			continue
+		}
 		ids = append(ids, instance.Id)
-		err = this.backend.SendProcessInstanceUpdate(instance)
+		history := historyById[instance.Id]
+		this.getInstanceHierarchy(instance.Id, instance.DefinitionId, history.SuperProcessInstanceId, history.RootProcessInstanceId).applyToInstance(&instance)
+		err = this.fullSync.SendProcessInstanceUpdate(instance)
 		if err != nil {
 			return err
 		}
 	}
-	return this.backend.SendProcessInstanceKnownIds(ids)
+	return this.fullSync.SendProcessInstanceKnownIds(ids)
 }
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// {"id_":"6b84bb08-750c-11eb-b54c-0242ac110006","rev_":1,"type_":"timer","lock_exp_time_":null,"lock_owner_":null,"exclusive_":true,"execution_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","process_instance_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","process_def_id_":"ExampleId:1:686e7a53-750c-11eb-b54c-0242ac110006","process_def_key_":"ExampleId","retries_":3,"exception_stack_id_":null,"exception_msg_":null,"failed_act_id_":null,"duedate_":"2021-02-22T13:49:36.886","repeat_":null,"repeat_offset_":0,"handler_type_":"timer-intermediate-transition","handler_cfg_":"IntermediateThrowEvent_1","deployment_id_":"686e7a52-750c-11eb-b54c-0242ac110006","suspension_state_":1,"job_def_id_":"6b84bb09-750c-11eb-b54c-0242ac110006","priority_":0,"sequence_counter_":1,"tenant_id_":"user","create_time_":"2021-02-22T12:49:36.886","last_failure_log_id_":null}
+type JobInPg struct {
+	Id                   string  `json:"id_"`
+	Type                 string  `json:"type_"`
+	ExecutionId          string  `json:"execution_id_"`
+	ProcessInstanceId    string  `json:"process_instance_id_"`
+	ProcessDefinitionId  string  `json:"process_def_id_"`
+	ProcessDefinitionKey string  `json:"process_def_key_"`
+	Retries              int64   `json:"retries_"`
+	ExceptionMessage     string  `json:"exception_msg_"`
+	FailedActivityId     string  `json:"failed_act_id_"`
+	DueDate              string  `json:"duedate_"`
+	SuspensionState      int     `json:"suspension_state_"` //1: active, 2: suspended
+	JobDefinitionId      string  `json:"job_def_id_"`
+	Priority             float64 `json:"priority_"`
+	TenantId             string  `json:"tenant_id_"`
+	CreateTime           string  `json:"create_time_"`
+}
+
+// isSyncedJob filters timer and async continuation jobs; e.g. the history cleanup job is ignored
+func isSyncedJob(jobType string) bool {
+	return jobType == "timer" || jobType == "message"
+}
+
+func getJob(element JobInPg) camundamodel.Job {
+	return camundamodel.Job{
+		Id:                   element.Id,
+		Type:                 element.Type,
+		JobDefinitionId:      element.JobDefinitionId,
+		ProcessInstanceId:    element.ProcessInstanceId,
+		ProcessDefinitionId:  element.ProcessDefinitionId,
+		ProcessDefinitionKey: element.ProcessDefinitionKey,
+		ExecutionId:          element.ExecutionId,
+		ExceptionMessage:     element.ExceptionMessage,
+		FailedActivityId:     element.FailedActivityId,
+		Retries:              element.Retries,
+		DueDate:              element.DueDate,
+		Suspended:            element.SuspensionState == 2,
+		Priority:             element.Priority,
+		TenantId:             element.TenantId,
+		CreateTime:           element.CreateTime,
+	}
+}
+
+func (this *Controller) NotifyJobUpdate(extra string) {
+	element := JobInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal job in NotifyJobUpdate(): ", err)
+		return
+	}
+	if !isSyncedJob(element.Type) || !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	err = this.backend.SendJobUpdate(getJob(element))
+	if err != nil {
+		log.Println("ERROR: unable to send job update in NotifyJobUpdate(): ", err)
+		return
+	}
+}
+
+func (this *Controller) NotifyJobDelete(extra string) {
+	element := JobInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal job in NotifyJobDelete(): ", err)
+		return
+	}
+	if !isSyncedJob(element.Type) || !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	err = this.backend.SendJobDelete(element.Id)
+	if err != nil {
+		log.Println("ERROR: unable to send job delete in NotifyJobDelete(): ", err)
+		return
+	}
+}
+
+func (this *Controller) SendCurrentJobs() error {
+	if !this.config.SyncJobs {
+		return nil
+	}
+	jobs, err := this.camunda.GetJobList(UserId)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, job := range jobs {
+		if !this.isSyncedDefinition(job.ProcessDefinitionId) {
+			continue
+		}
+		ids = append(ids, job.Id)
+		err = this.fullSync.SendJobUpdate(job)
+		if err != nil {
+			return err
+		}
+	}
+	return this.fullSync.SendJobKnownIds(ids)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func TestIsSyncedJob(t *testing.T) {
+	for jobType, expected := range map[string]bool{"timer": true, "message": true, "history-cleanup": false, "": false} {
+		if isSyncedJob(jobType) != expected {
+			t.Error(jobType)
+		}
+	}
+}
+
+func TestGetJob(t *testing.T) {
+	row := `{"id_":"job1","rev_":1,"type_":"timer","lock_exp_time_":null,"lock_owner_":null,"exclusive_":true,"execution_id_":"exec1","process_instance_id_":"inst1","process_def_id_":"ExampleId:1:def","process_def_key_":"ExampleId","retries_":3,"exception_stack_id_":null,"exception_msg_":"failed","failed_act_id_":"Task_1","duedate_":"2021-02-22T13:49:36.886","repeat_":null,"repeat_offset_":0,"handler_type_":"timer-intermediate-transition","handler_cfg_":"IntermediateThrowEvent_1","deployment_id_":"depl1","suspension_state_":2,"job_def_id_":"jobdef1","priority_":5,"sequence_counter_":1,"tenant_id_":"user","create_time_":"2021-02-22T12:49:36.886","last_failure_log_id_":null}`
+	element := JobInPg{}
+	err := json.Unmarshal([]byte(row), &element)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := camundamodel.Job{
+		Id:                   "job1",
+		Type:                 "timer",
+		JobDefinitionId:      "jobdef1",
+		ProcessInstanceId:    "inst1",
+		ProcessDefinitionId:  "ExampleId:1:def",
+		ProcessDefinitionKey: "ExampleId",
+		ExecutionId:          "exec1",
+		ExceptionMessage:     "failed",
+		FailedActivityId:     "Task_1",
+		Retries:              3,
+		DueDate:              "2021-02-22T13:49:36.886",
+		Suspended:            true,
+		Priority:             5,
+		TenantId:             "user",
+		CreateTime:           "2021-02-22T12:49:36.886",
+	}
+	if job := getJob(element); !reflect.DeepEqual(job, expected) {
+		t.Errorf("\n%#v\n%#v", job, expected)
+	}
+}
+
+func TestGetJobList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/engine-rest/job" {
+			http.NotFound(writer, request)
+			return
+		}
+		query := request.URL.Query()
+		switch {
+		case query.Get("timers") == "true":
+			json.NewEncoder(writer).Encode(camundamodel.Jobs{{Id: "timer1"}, {Id: "timer2"}})
+		case query.Get("messages") == "true":
+			json.NewEncoder(writer).Encode(camundamodel.Jobs{{Id: "message1"}})
+		default:
+			//unfiltered jobs like the history cleanup must not be requested
+			json.NewEncoder(writer).Encode(camundamodel.Jobs{{Id: "cleanup"}})
+		}
+	}))
+	defer server.Close()
+	config := configuration.Config{}
+	jobs, err := camunda.New(config, shards.Shards(server.URL)).GetJobList(UserId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := camundamodel.Jobs{{Id: "timer1", Type: "timer"}, {Id: "timer2", Type: "timer"}, {Id: "message1", Type: "message"}}
+	if !reflect.DeepEqual(jobs, expected) {
+		t.Errorf("%#v", jobs)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+)
+
+type keepAliveDeployments struct {
+	list []metadata.Metadata
+}
+
+type keepAliveState struct {
+	mux          sync.Mutex //held during camunda requests; other deployments are not blocked
+	lastStart    time.Time
+	retryPending bool
+}
+
+// getKeepAliveMinStartInterval defaults to one minute; "-" disables the limit
+func (this *Controller) getKeepAliveMinStartInterval() time.Duration {
+	if this.config.KeepAliveMinStartInterval == "" {
+		return time.Minute
+	}
+	if this.config.KeepAliveMinStartInterval == "-" {
+		return 0
+	}
+	interval, err := time.ParseDuration(this.config.KeepAliveMinStartInterval)
+	if err != nil {
+		log.Println("WARNING: unable to parse keep_alive_min_start_interval", this.config.KeepAliveMinStartInterval, err)
+		return time.Minute
+	}
+	return interval
+}
+
+// ensureKeepAliveDeployments starts keep-alive deployments without running instance (e.g. after a gateway reboot)
+func (this *Controller) ensureKeepAliveDeployments() {
+	for _, md := range this.listKeepAliveDeployments() {
+		this.ensureKeepAlive(md)
+	}
+}
+
+// onInstanceEnded restarts the keep-alive deployment of the process definition
+func (this *Controller) onInstanceEnded(processDefinitionId string) {
+	list := this.listKeepAliveDeployments()
+	if len(list) == 0 {
+		return
+	}
+	definition, err := this.getProcessDefinition(processDefinitionId)
+	if err != nil {
+		log.Println("WARNING: unable to get process definition for keep-alive check", processDefinitionId, err)
+		return
+	}
+	for _, md := range list {
+		if md.CamundaDeploymentId == definition.DeploymentId {
+			this.ensureKeepAlive(md)
+		}
+	}
+}
+
+// listKeepAliveDeployments returns the cached keep-alive deployments; the cache is reset by invalidateKeepAlive
+func (this *Controller) listKeepAliveDeployments() (result []metadata.Metadata) {
+	if this.metadata.IsPlaceholder() {
+		return nil
+	}
+	this.keepAliveMux.Lock()
+	cached := this.keepAliveDeployments
+	generation := this.keepAliveGeneration
+	this.keepAliveMux.Unlock()
+	if cached != nil {
+		return cached.list
+	}
+	list, err := this.metadata.List()
+	if err != nil {
+		log.Println("ERROR: unable to list deployment metadata for keep-alive check", err)
+		return nil
+	}
+	for _, md := range list {
+		if md.DeploymentModel.KeepAlive != nil {
+			result = append(result, md)
+		}
+	}
+	this.keepAliveMux.Lock()
+	defer this.keepAliveMux.Unlock()
+	if generation == this.keepAliveGeneration {
+		//not invalidated while listing
+		this.keepAliveDeployments = &keepAliveDeployments{list: result}
+	}
+	return result
+}
+
+// invalidateKeepAlive resets the cached keep-alive deployments and drops the start state of the deployment
+// must be called if a deployment is created or removed
+func (this *Controller) invalidateKeepAlive(camundaDeploymentId string) {
+	this.keepAliveMux.Lock()
+	defer this.keepAliveMux.Unlock()
+	this.keepAliveDeployments = nil
+	this.keepAliveGeneration++
+	delete(this.keepAlive, camundaDeploymentId)
+}
+
+func (this *Controller) getKeepAliveState(camundaDeploymentId string) *keepAliveState {
+	this.keepAliveMux.Lock()
+	defer this.keepAliveMux.Unlock()
+	if this.keepAlive == nil {
+		this.keepAlive = map[string]*keepAliveState{}
+	}
+	state, ok := this.keepAlive[camundaDeploymentId]
+	if !ok {
+		state = &keepAliveState{}
+		this.keepAlive[camundaDeploymentId] = state
+	}
+	return state
+}
+
+// ensureKeepAlive starts the deployment if no instance is running
+// starts of the same deployment are delayed to keep_alive_min_start_interval
+func (this *Controller) ensureKeepAlive(md metadata.Metadata) {
+	keepAlive := md.DeploymentModel.KeepAlive
+	if keepAlive == nil {
+		return
+	}
+	state := this.getKeepAliveState(md.CamundaDeploymentId)
+	state.mux.Lock()
+	defer state.mux.Unlock()
+	if state.retryPending {
+		return
+	}
+	if wait := time.Until(state.lastStart.Add(this.getKeepAliveMinStartInterval())); wait > 0 {
+		state.retryPending = true
+		time.AfterFunc(wait, func() {
+			state.mux.Lock()
+			state.retryPending = false
+			state.mux.Unlock()
+			current, err := this.metadata.Read(md.CamundaDeploymentId)
+			if err != nil {
+				//deployment removed in the meantime
+				return
+			}
+			this.ensureKeepAlive(current)
+		})
+		return
+	}
+	definitions, err := this.camunda.GetDefinitionByDeploymentVid(md.CamundaDeploymentId, UserId)
+	if err != nil || len(definitions) == 0 {
+		log.Println("WARNING: unable to get process definition of keep-alive deployment", md.CamundaDeploymentId, err)
+		return
+	}
+	running, err := this.camunda.GetProcessInstancesByDefinition(definitions[0].Id, UserId)
+	if err != nil {
+		log.Println("WARNING: unable to get running instances of keep-alive deployment", md.CamundaDeploymentId, err)
+		return
+	}
+	if len(running) > 0 {
+		return
+	}
+	state.lastStart = time.Now()
+	err = this.autoStartDeployment(md.CamundaDeploymentId, keepAlive.BusinessKey, model.MergeTypedVariables(keepAlive.Parameter, keepAlive.TypedParameter))
+	if err != nil {
+		log.Println("ERROR: unable to start keep-alive deployment", md.CamundaDeploymentId, err)
+	}
+}
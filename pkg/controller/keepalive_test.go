@@ -0,0 +1,122 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+)
+
+func TestEnsureKeepAlive(t *testing.T) {
+	md := metadata.Metadata{
+		CamundaDeploymentId: "c1",
+		DeploymentModel: model.FogDeploymentMessage{
+			KeepAlive: &model.KeepAlive{BusinessKey: "monitor"},
+		},
+	}
+	fake := &fakeInstances{running: map[string]string{}}
+	ctrl := newTestController(t, configuration.Config{KeepAliveMinStartInterval: "200ms"}, fake, md)
+
+	startCount := func() int {
+		fake.mux.Lock()
+		defer fake.mux.Unlock()
+		return len(fake.started)
+	}
+	endAll := func() {
+		fake.mux.Lock()
+		defer fake.mux.Unlock()
+		fake.running = map[string]string{}
+	}
+
+	ctrl.ensureKeepAliveDeployments()
+	if count := startCount(); count != 1 {
+		t.Error("expected start", count)
+	}
+
+	//instance is running
+	ctrl.ensureKeepAlive(md)
+	if count := startCount(); count != 1 {
+		t.Error("unexpected start", count)
+	}
+
+	//restart is delayed by keep_alive_min_start_interval
+	endAll()
+	ctrl.ensureKeepAlive(md)
+	ctrl.ensureKeepAlive(md)
+	if count := startCount(); count != 1 {
+		t.Error("unexpected start", count)
+	}
+	time.Sleep(400 * time.Millisecond)
+	if count := startCount(); count != 2 {
+		t.Error("expected delayed start", count)
+	}
+}
+
+func TestKeepAliveMinStartIntervalDefault(t *testing.T) {
+	for config, expected := range map[string]time.Duration{"": time.Minute, "-": 0, "10s": 10 * time.Second} {
+		ctrl := &Controller{config: configuration.Config{KeepAliveMinStartInterval: config}}
+		if interval := ctrl.getKeepAliveMinStartInterval(); interval != expected {
+			t.Error(config, interval)
+		}
+	}
+}
+
+func TestListKeepAliveDeploymentsCache(t *testing.T) {
+	ctrl := newTestController(t, configuration.Config{}, nil)
+
+	store := func(camundaId string) {
+		err := ctrl.metadata.Store(metadata.Metadata{
+			CamundaDeploymentId: camundaId,
+			DeploymentModel:     model.FogDeploymentMessage{KeepAlive: &model.KeepAlive{}},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	store("c1")
+	if list := ctrl.listKeepAliveDeployments(); len(list) != 1 {
+		t.Error(list)
+	}
+	ctrl.getKeepAliveState("c1")
+
+	//cached until invalidated
+	store("c2")
+	if list := ctrl.listKeepAliveDeployments(); len(list) != 1 {
+		t.Error(list)
+	}
+	ctrl.invalidateKeepAlive("c2")
+	if list := ctrl.listKeepAliveDeployments(); len(list) != 2 {
+		t.Error(list)
+	}
+
+	err := ctrl.metadata.Remove("c1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ctrl.invalidateKeepAlive("c1")
+	if list := ctrl.listKeepAliveDeployments(); len(list) != 1 || list[0].CamundaDeploymentId != "c2" {
+		t.Error(list)
+	}
+	if _, ok := ctrl.keepAlive["c1"]; ok {
+		t.Error("expected removed keep-alive state")
+	}
+}
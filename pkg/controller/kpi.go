@@ -0,0 +1,270 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+)
+
+// kpiAggregator counts process instances per deployment from the history notifications
+type kpiAggregator struct {
+	mux           sync.Mutex
+	length        time.Duration
+	since         time.Time                   //start of the client; starts before are unknown
+	current       time.Time                   //start of the current window
+	windows       map[string]*model.KpiWindow //by camunda deployment id
+	changed       map[string]bool             //by camunda deployment id; windows to be persisted
+	started       map[string]bool             //by process instance id; running instances with counted start
+	ended         map[string]bool             //by process instance id; instances with counted end in the current window
+	endedPrevious map[string]bool             //by process instance id; instances with counted end in the previous window
+}
+
+func newKpiAggregator(length time.Duration, now time.Time) *kpiAggregator {
+	return &kpiAggregator{
+		length:        length,
+		since:         now,
+		current:       now.Truncate(length),
+		windows:       map[string]*model.KpiWindow{},
+		changed:       map[string]bool{},
+		started:       map[string]bool{},
+		ended:         map[string]bool{},
+		endedPrevious: map[string]bool{},
+	}
+}
+
+// startKpi loads the persisted kpi windows and publishes a summary after each window
+func (this *Controller) startKpi(ctx context.Context) {
+	if this.config.KpiWindow == "" || this.config.KpiWindow == "-" || this.metadata.IsPlaceholder() {
+		return
+	}
+	length, err := time.ParseDuration(this.config.KpiWindow)
+	if err != nil || length <= 0 {
+		log.Println("WARNING: unable to parse kpi window", this.config.KpiWindow, err)
+		return
+	}
+	list, err := this.metadata.List()
+	if err != nil {
+		log.Println("ERROR: unable to load kpi windows", err)
+		return
+	}
+	this.kpi = newKpiAggregator(length, time.Now())
+	for _, md := range list {
+		if md.Kpi != nil {
+			this.kpi.windows[md.CamundaDeploymentId] = md.Kpi
+		}
+	}
+	go func() {
+		ticker := time.NewTicker(min(length, time.Minute))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				this.persistKpi()
+				return
+			case t := <-ticker.C:
+				summary := this.rolloverKpi(t)
+				if len(summary.Deployments) > 0 {
+					err := this.backend.SendKpiSummary(summary)
+					if err != nil {
+						log.Println("ERROR: unable to send kpi summary", err)
+					}
+				}
+				this.persistKpi()
+			}
+		}
+	}()
+}
+
+// getKpiWindow must be called with locked kpi.mux
+func (this *kpiAggregator) getKpiWindow(camundaDeploymentId string) *model.KpiWindow {
+	window, ok := this.windows[camundaDeploymentId]
+	if !ok {
+		window = &model.KpiWindow{Start: this.current}
+		this.windows[camundaDeploymentId] = window
+	}
+	this.changed[camundaDeploymentId] = true
+	return window
+}
+
+func (this *Controller) onKpiHistory(element ProcessInstanceHistoryInPg, now time.Time) {
+	if this.kpi == nil {
+		return
+	}
+	deploymentId := this.getDeploymentIdOfDefinition(element.ProcessDefinitionId)
+	if deploymentId == "" {
+		return
+	}
+	this.kpi.mux.Lock()
+	defer this.kpi.mux.Unlock()
+	if element.EndTime == "" {
+		if this.kpi.started[element.Id] {
+			return
+		}
+		this.kpi.started[element.Id] = true
+		this.kpi.getKpiWindow(deploymentId).Started++
+		return
+	}
+	if this.kpi.ended[element.Id] || this.kpi.endedPrevious[element.Id] {
+		return
+	}
+	this.kpi.ended[element.Id] = true
+	window := this.kpi.getKpiWindow(deploymentId)
+	if this.kpi.started[element.Id] {
+		delete(this.kpi.started, element.Id)
+	} else if now.Sub(this.kpi.since) > time.Duration(element.DurationInMillis)*time.Millisecond {
+		//started while the client was running but without separate start notification (e.g. synchronous processes)
+		window.Started++
+	}
+	if element.State != "COMPLETED" {
+		window.Terminated++
+		return
+	}
+	window.Completed++
+	addKpiDuration(window, int64(element.DurationInMillis))
+}
+
+func (this *Controller) onKpiIncident(processDefinitionId string) {
+	if this.kpi == nil {
+		return
+	}
+	deploymentId := this.getDeploymentIdOfDefinition(processDefinitionId)
+	if deploymentId == "" {
+		return
+	}
+	this.kpi.mux.Lock()
+	defer this.kpi.mux.Unlock()
+	this.kpi.getKpiWindow(deploymentId).Incidents++
+}
+
+func addKpiDuration(window *model.KpiWindow, durationMs int64) {
+	for len(window.DurationBuckets) <= len(model.KpiDurationBucketsMs) {
+		window.DurationBuckets = append(window.DurationBuckets, 0)
+	}
+	index := sort.Search(len(model.KpiDurationBucketsMs), func(i int) bool {
+		return durationMs <= model.KpiDurationBucketsMs[i]
+	})
+	window.DurationBuckets[index]++
+	window.DurationSumMs += durationMs
+	window.DurationMaxMs = max(window.DurationMaxMs, durationMs)
+}
+
+// getKpiPercentile returns the upper bound of the bucket containing the percentile, limited to the max duration
+func getKpiPercentile(window model.KpiWindow, percentile float64) int64 {
+	total := int64(0)
+	for _, count := range window.DurationBuckets {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(percentile * float64(total)))
+	cumulated := int64(0)
+	for i, count := range window.DurationBuckets {
+		cumulated += count
+		if cumulated >= rank && i < len(model.KpiDurationBucketsMs) {
+			return min(model.KpiDurationBucketsMs[i], window.DurationMaxMs)
+		}
+	}
+	return window.DurationMaxMs
+}
+
+// rolloverKpi removes and returns windows that ended before now
+func (this *Controller) rolloverKpi(now time.Time) (summary model.KpiSummary) {
+	summary = model.KpiSummary{Time: now, Deployments: []model.DeploymentKpi{}}
+	if this.kpi == nil {
+		return summary
+	}
+	this.kpi.mux.Lock()
+	defer this.kpi.mux.Unlock()
+	current := now.Truncate(this.kpi.length)
+	if this.kpi.current.Before(current) {
+		this.kpi.endedPrevious = this.kpi.ended
+		this.kpi.ended = map[string]bool{}
+		this.kpi.current = current
+	}
+	for camundaDeploymentId, window := range this.kpi.windows {
+		if !window.Start.Before(current) {
+			continue
+		}
+		kpi := model.DeploymentKpi{
+			CamundaDeploymentId: camundaDeploymentId,
+			WindowStart:         window.Start,
+			WindowEnd:           window.Start.Add(this.kpi.length),
+			Started:             window.Started,
+			Completed:           window.Completed,
+			Terminated:          window.Terminated,
+			Incidents:           window.Incidents,
+			DurationP50Ms:       getKpiPercentile(*window, 0.5),
+			DurationP90Ms:       getKpiPercentile(*window, 0.9),
+			DurationP99Ms:       getKpiPercentile(*window, 0.99),
+			DurationMaxMs:       window.DurationMaxMs,
+		}
+		if window.Completed > 0 {
+			kpi.DurationMeanMs = window.DurationSumMs / window.Completed
+		}
+		if md, err := this.metadata.Read(camundaDeploymentId); err == nil {
+			kpi.DeploymentId = md.DeploymentModel.Id
+		}
+		if this.isSyncedDeployment(camundaDeploymentId) {
+			summary.Deployments = append(summary.Deployments, kpi)
+		}
+		delete(this.kpi.windows, camundaDeploymentId)
+		this.kpi.changed[camundaDeploymentId] = true
+	}
+	sort.Slice(summary.Deployments, func(i, j int) bool {
+		return summary.Deployments[i].CamundaDeploymentId < summary.Deployments[j].CamundaDeploymentId
+	})
+	return summary
+}
+
+// persistKpi stores changed windows in the deployment metadata; closed windows are removed
+func (this *Controller) persistKpi() {
+	if this.kpi == nil {
+		return
+	}
+	this.kpi.mux.Lock()
+	changed := map[string]*model.KpiWindow{}
+	for camundaDeploymentId := range this.kpi.changed {
+		if window, ok := this.kpi.windows[camundaDeploymentId]; ok {
+			temp := *window
+			temp.DurationBuckets = append([]int64{}, window.DurationBuckets...)
+			changed[camundaDeploymentId] = &temp
+		} else {
+			changed[camundaDeploymentId] = nil
+		}
+	}
+	this.kpi.changed = map[string]bool{}
+	this.kpi.mux.Unlock()
+
+	for camundaDeploymentId, window := range changed {
+		err := this.updateMetadata(camundaDeploymentId, func(md *metadata.Metadata) {
+			md.Kpi = window
+		})
+		if err != nil && this.config.Debug {
+			//deployments without metadata are not persisted
+			log.Println("DEBUG: unable to persist kpi window", camundaDeploymentId, err)
+		}
+	}
+}
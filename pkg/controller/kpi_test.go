@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func TestKpiAggregation(t *testing.T) {
+	md := metadata.Metadata{CamundaDeploymentId: "c1"}
+	md.DeploymentModel.Id = "d1"
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	ctrl := newTestController(t, configuration.Config{}, nil, md)
+	ctrl.definitions = map[string]camundamodel.ProcessDefinition{"def1": {Id: "def1", DeploymentId: "c1"}}
+	ctrl.kpi = newKpiAggregator(time.Hour, start)
+	now := start.Add(10 * time.Minute)
+	history := func(id string, state string, durationMs float64) ProcessInstanceHistoryInPg {
+		result := ProcessInstanceHistoryInPg{Id: id, ProcessDefinitionId: "def1", State: state, DurationInMillis: durationMs}
+		if state != "ACTIVE" {
+			result.EndTime = "2026-01-01T10:10:00.000"
+		}
+		return result
+	}
+
+	ctrl.onKpiHistory(history("i1", "ACTIVE", 0), now)
+	ctrl.onKpiHistory(history("i1", "ACTIVE", 0), now) //repeated update
+	ctrl.onKpiHistory(history("i1", "COMPLETED", 40), now)
+	ctrl.onKpiHistory(history("i1", "COMPLETED", 40), now)                                            //repeated update
+	ctrl.onKpiHistory(history("i2", "COMPLETED", 800), now)                                           //synchronous process without start notification
+	ctrl.onKpiHistory(history("i3", "EXTERNALLY_TERMINATED", float64(time.Hour.Milliseconds())), now) //started before the client
+	ctrl.onKpiIncident("def1")
+
+	ctrl.persistKpi()
+	md, err := ctrl.metadata.Read("c1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if md.Kpi == nil || md.Kpi.Started != 2 || md.Kpi.Completed != 2 || md.Kpi.Terminated != 1 || md.Kpi.Incidents != 1 {
+		t.Errorf("%#v", md.Kpi)
+	}
+
+	if summary := ctrl.rolloverKpi(start.Add(59 * time.Minute)); len(summary.Deployments) != 0 {
+		t.Errorf("%#v", summary)
+	}
+	summary := ctrl.rolloverKpi(start.Add(61 * time.Minute))
+	if len(summary.Deployments) != 1 {
+		t.Errorf("%#v", summary)
+		return
+	}
+	expected := model.DeploymentKpi{
+		CamundaDeploymentId: "c1",
+		DeploymentId:        "d1",
+		WindowStart:         start,
+		WindowEnd:           start.Add(time.Hour),
+		Started:             2,
+		Completed:           2,
+		Terminated:          1,
+		Incidents:           1,
+		DurationMeanMs:      420,
+		DurationP50Ms:       50,
+		DurationP90Ms:       800,
+		DurationP99Ms:       800,
+		DurationMaxMs:       800,
+	}
+	if summary.Deployments[0] != expected {
+		t.Errorf("%#v", summary.Deployments[0])
+	}
+
+	//ended instances of the previous window are not counted again
+	ctrl.onKpiHistory(history("i1", "COMPLETED", 40), start.Add(62*time.Minute))
+	if len(ctrl.kpi.windows) != 0 {
+		t.Errorf("%#v", ctrl.kpi.windows)
+	}
+
+	ctrl.persistKpi()
+	md, err = ctrl.metadata.Read("c1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if md.Kpi != nil {
+		t.Errorf("%#v", md.Kpi)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/etree"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+const camundaDateFormat = "2006-01-02T15:04:05.000-0700"
+
+// getKnownMetadata returns the stored metadata of the deployment
+// returns empty metadata if it is unknown (no metadata storage, deployment created before the metadata was stored, ...)
+func (this *Controller) getKnownMetadata(camundaDeploymentId string) metadata.Metadata {
+	if this.metadata == nil || this.metadata.IsPlaceholder() {
+		return metadata.Metadata{}
+	}
+	md, err := this.metadata.Read(camundaDeploymentId)
+	if err != nil {
+		return metadata.Metadata{}
+	}
+	return md
+}
+
+// CoerceStartParameter checks the parameter of a start command against the declared form variables of the process
+// and converts the values to the declared camunda types; the result contains camundamodel.Variable values
+// required are the names of parameter without which the start is rejected (see GetRequiredStartParameter)
+// missing parameter are otherwise left to camunda (form variables without default value are reported as null)
+// if declared is nil, the parameter are returned unchanged
+// typed parameter (camundamodel.Variable values) must match the declared type or have no type
+func CoerceStartParameter(declared map[string]camundamodel.Variable, required []string, parameter map[string]interface{}) (result map[string]interface{}, err error) {
+	if declared == nil {
+		return NormalizeVariables(parameter)
+	}
+	messages := []string{}
+	result = map[string]interface{}{}
+	for name, value := range parameter {
+		variable, ok := declared[name]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("unknown parameter '%v'", name))
+			continue
+		}
+		if typedValue, isTyped := value.(camundamodel.Variable); isTyped {
+			if typedValue.Type != "" && !strings.EqualFold(typedValue.Type, variable.Type) {
+				messages = append(messages, fmt.Sprintf("parameter '%v': type %v does not match declared type %v", name, typedValue.Type, variable.Type))
+				continue
+			}
+			if typedValue.Type != "" || typedValue.ValueInfo != nil {
+				typedValue.Type = variable.Type
+				typedValue, err = NormalizeVariable(typedValue)
+				if err != nil {
+					messages = append(messages, fmt.Sprintf("parameter '%v': %v", name, err.Error()))
+					continue
+				}
+				result[name] = typedValue
+				continue
+			}
+			value = typedValue.Value
+		}
+		if value == nil {
+			continue
+		}
+		coerced, typed, err := coerceValue(variable.Type, value)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("parameter '%v': %v", name, err.Error()))
+			continue
+		}
+		if typed {
+			result[name] = camundamodel.Variable{Value: coerced, Type: variable.Type}
+		} else {
+			result[name] = coerced
+		}
+	}
+	for _, name := range required {
+		if _, ok := result[name]; !ok {
+			messages = append(messages, fmt.Sprintf("missing required parameter '%v'", name))
+		}
+	}
+	if len(messages) > 0 {
+		slices.Sort(messages)
+		return nil, errors.New("invalid start parameter: " + strings.Join(messages, "; "))
+	}
+	return result, nil
+}
+
+// GetRequiredStartParameter returns the start form fields of the xml with a camunda:validation "required" constraint
+func GetRequiredStartParameter(xml string) (result []string) {
+	doc := etree.NewDocument()
+	err := doc.ReadFromString(xml)
+	if err != nil {
+		return nil
+	}
+	for _, field := range doc.FindElements("//bpmn:startEvent//camunda:formField") {
+		id := field.SelectAttrValue("id", "")
+		if id != "" && field.FindElement("camunda:validation/camunda:constraint[@name='required']") != nil {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// NormalizeVariables normalizes all camundamodel.Variable values; plain values are returned unchanged
+func NormalizeVariables(variables map[string]interface{}) (result map[string]interface{}, err error) {
+	result = map[string]interface{}{}
+	for name, value := range variables {
+		if variable, ok := value.(camundamodel.Variable); ok {
+			value, err = NormalizeVariable(variable)
+			if err != nil {
+				return nil, fmt.Errorf("variable '%v': %w", name, err)
+			}
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// NormalizeVariable converts the value of a typed variable to the format expected by camunda
+// Json and Object values may be given as json string or as json value
+// Bytes values must be base64 encoded; Object values need valueInfo.objectTypeName
+func NormalizeVariable(variable camundamodel.Variable) (result camundamodel.Variable, err error) {
+	result = variable
+	if variable.Type == "" || variable.Value == nil {
+		return result, nil
+	}
+	if !strings.EqualFold(variable.Type, "object") {
+		result.Value, _, err = coerceValue(variable.Type, variable.Value)
+		return result, err
+	}
+	valueInfo, ok := variable.ValueInfo.(map[string]interface{})
+	if !ok || valueInfo["objectTypeName"] == nil || valueInfo["objectTypeName"] == "" {
+		return result, errors.New("missing valueInfo.objectTypeName for Object")
+	}
+	info := map[string]interface{}{}
+	for key, value := range valueInfo {
+		info[key] = value
+	}
+	if info["serializationDataFormat"] == nil {
+		info["serializationDataFormat"] = "application/json"
+	}
+	result.ValueInfo = info
+	if _, isString := variable.Value.(string); isString {
+		return result, nil
+	}
+	if info["serializationDataFormat"] != "application/json" {
+		return result, fmt.Errorf("unable to serialize Object value as %v", info["serializationDataFormat"])
+	}
+	b, err := json.Marshal(variable.Value)
+	if err != nil {
+		return result, err
+	}
+	result.Value = string(b)
+	return result, nil
+}
+
+// coerceValue converts value to the given camunda type
+// typed is false for unknown types, which are passed unchanged
+func coerceValue(camundaType string, value interface{}) (result interface{}, typed bool, err error) {
+	switch strings.ToLower(camundaType) {
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, true, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true, nil
+		case bool:
+			return strconv.FormatBool(v), true, nil
+		}
+	case "long", "integer", "short":
+		switch v := value.(type) {
+		case float64:
+			if v == math.Trunc(v) {
+				return int64(v), true, nil
+			}
+		case string:
+			i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err == nil {
+				return i, true, nil
+			}
+		}
+	case "double":
+		switch v := value.(type) {
+		case float64:
+			return v, true, nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err == nil {
+				return f, true, nil
+			}
+		}
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, true, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err == nil {
+				return b, true, nil
+			}
+		}
+	case "date":
+		if v, ok := value.(string); ok {
+			for _, format := range []string{camundaDateFormat, time.RFC3339Nano, time.DateOnly} {
+				t, err := time.Parse(format, v)
+				if err == nil {
+					return t.Format(camundaDateFormat), true, nil
+				}
+			}
+		}
+	case "bytes":
+		if v, ok := value.(string); ok {
+			if _, err := base64.StdEncoding.DecodeString(v); err != nil {
+				return nil, false, errors.New("bytes value is not base64 encoded")
+			}
+			return v, true, nil
+		}
+	case "json":
+		if v, ok := value.(string); ok {
+			if !json.Valid([]byte(v)) {
+				return nil, false, errors.New("invalid json string")
+			}
+			return v, true, nil
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, false, err
+		}
+		return string(b), true, nil
+	default:
+		//unknown or untyped form variables are passed unchanged
+		return value, false, nil
+	}
+	return nil, false, fmt.Errorf("unable to use %#v as %v", value, camundaType)
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func TestCoerceStartParameter(t *testing.T) {
+	declared := map[string]camundamodel.Variable{
+		"count":   {Type: "Long"},
+		"factor":  {Type: "Double", Value: 1.5},
+		"enabled": {Type: "Boolean", Value: false},
+		"name":    {Type: "String", Value: "default"},
+		"since":   {Type: "Date", Value: "2026-01-01T00:00:00.000+0000"},
+		"config":  {Type: "Json", Value: "{}"},
+		"other":   {Type: "Null", Value: "x"},
+	}
+
+	t.Run("coerce", func(t *testing.T) {
+		result, err := CoerceStartParameter(declared, nil, map[string]interface{}{
+			"count":   "42",
+			"factor":  float64(2),
+			"enabled": "true",
+			"name":    float64(13),
+			"since":   "2026-01-14T10:30:00Z",
+			"config":  map[string]interface{}{"foo": "bar"},
+			"other":   "y",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := map[string]interface{}{
+			"count":   camundamodel.Variable{Type: "Long", Value: int64(42)},
+			"factor":  camundamodel.Variable{Type: "Double", Value: float64(2)},
+			"enabled": camundamodel.Variable{Type: "Boolean", Value: true},
+			"name":    camundamodel.Variable{Type: "String", Value: "13"},
+			"since":   camundamodel.Variable{Type: "Date", Value: "2026-01-14T10:30:00.000+0000"},
+			"config":  camundamodel.Variable{Type: "Json", Value: `{"foo":"bar"}`},
+			"other":   "y",
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := CoerceStartParameter(declared, []string{"count"}, map[string]interface{}{
+			"factor":  "abc",
+			"enabled": float64(1),
+			"unknown": 1,
+		})
+		if err == nil {
+			t.Error("expected error")
+			return
+		}
+		for _, expected := range []string{"unknown parameter 'unknown'", "missing required parameter 'count'", "parameter 'factor'", "parameter 'enabled'"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Error(expected, err)
+			}
+		}
+	})
+
+	t.Run("form variables without default value are optional", func(t *testing.T) {
+		result, err := CoerceStartParameter(declared, nil, nil)
+		if err != nil || len(result) != 0 {
+			t.Error(result, err)
+		}
+	})
+
+	t.Run("unknown declaration", func(t *testing.T) {
+		parameter := map[string]interface{}{"foo": "bar"}
+		result, err := CoerceStartParameter(nil, nil, parameter)
+		if err != nil || !reflect.DeepEqual(result, parameter) {
+			t.Error(result, err)
+		}
+	})
+}
+
+func TestGetRequiredStartParameter(t *testing.T) {
+	xml := `<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn">
+  <bpmn:process id="p" isExecutable="true">
+    <bpmn:startEvent id="start">
+      <bpmn:extensionElements>
+        <camunda:formData>
+          <camunda:formField id="count" type="long">
+            <camunda:validation>
+              <camunda:constraint name="required" />
+            </camunda:validation>
+          </camunda:formField>
+          <camunda:formField id="factor" type="long">
+            <camunda:validation>
+              <camunda:constraint name="min" config="1" />
+            </camunda:validation>
+          </camunda:formField>
+          <camunda:formField id="name" type="string" />
+        </camunda:formData>
+      </bpmn:extensionElements>
+    </bpmn:startEvent>
+  </bpmn:process>
+</bpmn:definitions>`
+	if result := GetRequiredStartParameter(xml); !reflect.DeepEqual(result, []string{"count"}) {
+		t.Error(result)
+	}
+	if result := GetRequiredStartParameter("invalid"); result != nil {
+		t.Error(result)
+	}
+}
+
+func TestCoerceTypedStartParameter(t *testing.T) {
+	declared := map[string]camundamodel.Variable{
+		"count":  {Type: "Long"},
+		"config": {Type: "Json", Value: "{}"},
+	}
+	result, err := CoerceStartParameter(declared, nil, map[string]interface{}{
+		"count":  camundamodel.Variable{Value: "3"},
+		"config": camundamodel.Variable{Type: "json", Value: []interface{}{"a", "b"}},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := map[string]interface{}{
+		"count":  camundamodel.Variable{Type: "Long", Value: int64(3)},
+		"config": camundamodel.Variable{Type: "Json", Value: `["a","b"]`},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("%#v", result)
+	}
+
+	_, err = CoerceStartParameter(declared, nil, map[string]interface{}{
+		"count": camundamodel.Variable{Type: "String", Value: "3"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "does not match declared type") {
+		t.Error(err)
+	}
+}
+
+func TestNormalizeVariable(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    camundamodel.Variable
+		expected camundamodel.Variable
+		err      bool
+	}{
+		{
+			name:     "untyped",
+			input:    camundamodel.Variable{Value: map[string]interface{}{"foo": "bar"}},
+			expected: camundamodel.Variable{Value: map[string]interface{}{"foo": "bar"}},
+		},
+		{
+			name:     "json",
+			input:    camundamodel.Variable{Type: "Json", Value: map[string]interface{}{"foo": "bar"}},
+			expected: camundamodel.Variable{Type: "Json", Value: `{"foo":"bar"}`},
+		},
+		{
+			name:  "invalid json string",
+			input: camundamodel.Variable{Type: "Json", Value: `{"foo"`},
+			err:   true,
+		},
+		{
+			name:     "date",
+			input:    camundamodel.Variable{Type: "Date", Value: "2026-01-14"},
+			expected: camundamodel.Variable{Type: "Date", Value: "2026-01-14T00:00:00.000+0000"},
+		},
+		{
+			name:     "bytes",
+			input:    camundamodel.Variable{Type: "Bytes", Value: "aGVsbG8="},
+			expected: camundamodel.Variable{Type: "Bytes", Value: "aGVsbG8="},
+		},
+		{
+			name:  "invalid bytes",
+			input: camundamodel.Variable{Type: "Bytes", Value: "hello!"},
+			err:   true,
+		},
+		{
+			name: "object",
+			input: camundamodel.Variable{
+				Type:      "Object",
+				Value:     map[string]interface{}{"foo": "bar"},
+				ValueInfo: map[string]interface{}{"objectTypeName": "java.util.HashMap"},
+			},
+			expected: camundamodel.Variable{
+				Type:      "Object",
+				Value:     `{"foo":"bar"}`,
+				ValueInfo: map[string]interface{}{"objectTypeName": "java.util.HashMap", "serializationDataFormat": "application/json"},
+			},
+		},
+		{
+			name:  "object without type name",
+			input: camundamodel.Variable{Type: "Object", Value: `{"foo":"bar"}`},
+			err:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := NormalizeVariable(test.input)
+			if test.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("%#v", result)
+			}
+		})
+	}
+}
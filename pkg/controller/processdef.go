@@ -48,8 +48,10 @@ func (this *Controller) NotifyProcessDefUpdate(extra string) {
 	def, err := this.camunda.GetProcessDefinition(element.Id, UserId)
 	if err != nil {
 		log.Println("ERROR: unable to get process def in NotifyProcessDefUpdate(): ", err)
+		this.invalidateProcessDefinition(element.Id)
 		return
 	}
+	this.cacheProcessDefinition(def)
 	/*
 		// alternative to this.camunda.GetProcessDefinition(element.Id, UserId)
 		def := camundamodel.ProcessDefinition{
@@ -74,6 +76,9 @@ func (this *Controller) NotifyProcessDefUpdate(extra string) {
 		}
 	*/
 
+	if !this.isSyncedDeployment(def.DeploymentId) {
+		return
+	}
 	err = this.backend.SendProcessDefinitionUpdate(def)
 	if err != nil {
 		log.Println("ERROR: unable to send process def update in NotifyProcessDefUpdate(): ", err)
@@ -88,6 +93,10 @@ func (this *Controller) NotifyProcessDefDelete(extra string) {
 		log.Println("ERROR: unable to unmarshal process def in NotifyProcessDefDelete(): ", err)
 		return
 	}
+	this.invalidateProcessDefinition(element.Id)
+	if !this.isSyncedDeployment(element.DeploymentId) {
+		return
+	}
 	err = this.backend.SendProcessDefinitionDelete(element.Id)
 	if err != nil {
 		log.Println("ERROR: unable to send process def delete in NotifyProcessDefDelete(): ", err)
@@ -102,11 +111,14 @@ func (this *Controller) SendCurrentProcessDefs() error {
 	}
 	ids := []string{}
 	for _, instance := range instances {
+		if !this.isSyncedDeployment(instance.DeploymentId) {
+			continue
+		}
 		ids = append(ids, instance.Id)
-		err = this.backend.SendProcessDefinitionUpdate(instance)
+		err = this.fullSync.SendProcessDefinitionUpdate(instance)
 		if err != nil {
 			return err
 		}
 	}
-	return this.backend.SendProcessDefinitionKnownIds(ids)
+	return this.fullSync.SendProcessDefinitionKnownIds(ids)
 }
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rewrite
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/etree"
+)
+
+// Apply applies the rules in order to the xml and returns the modified xml and a description of each applied change
+func Apply(xml string, rules []configuration.RewriteRule) (result string, rewrites []string, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			log.Printf("%s: %s", r, debug.Stack())
+			err = errors.New(fmt.Sprint("Recovered Error: ", r))
+		}
+	}()
+	if len(rules) == 0 {
+		return xml, nil, nil
+	}
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(xml)
+	if err != nil {
+		return result, rewrites, err
+	}
+	for i, rule := range rules {
+		ruleRewrites, err := applyRule(doc, rule)
+		if err != nil {
+			return result, rewrites, fmt.Errorf("rewrite rule %v (%v): %w", i, rule.Action, err)
+		}
+		for _, rewrite := range ruleRewrites {
+			rewrites = append(rewrites, fmt.Sprintf("rewrite rule %v (%v): %v", i, rule.Action, rewrite))
+		}
+	}
+	result, err = doc.WriteToString()
+	return result, rewrites, err
+}
+
+// Validate checks the rules without applying them
+func Validate(rules []configuration.RewriteRule) error {
+	for i, rule := range rules {
+		err := validateRule(rule)
+		if err != nil {
+			return fmt.Errorf("rewrite rule %v (%v): %w", i, rule.Action, err)
+		}
+	}
+	return nil
+}
+
+func validateRule(rule configuration.RewriteRule) error {
+	_, err := compilePath(getPath(rule))
+	if err != nil {
+		return err
+	}
+	switch rule.Action {
+	case configuration.RewriteActionSetAttribute:
+		if rule.Attribute == "" {
+			return errors.New("missing attribute")
+		}
+	case configuration.RewriteActionPlaceholder:
+		if rule.Search == "" {
+			return errors.New("missing search")
+		}
+	case configuration.RewriteActionReplaceText, configuration.RewriteActionRemoveElement:
+	default:
+		return errors.New("unknown action")
+	}
+	return nil
+}
+
+func applyRule(doc *etree.Document, rule configuration.RewriteRule) (rewrites []string, err error) {
+	err = validateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	path, err := compilePath(getPath(rule))
+	if err != nil {
+		return nil, err
+	}
+	value := getValue(rule)
+	for _, element := range doc.FindElementsPath(path) {
+		switch rule.Action {
+		case configuration.RewriteActionSetAttribute:
+			element.CreateAttr(rule.Attribute, value)
+			rewrites = append(rewrites, fmt.Sprintf("%v='%v' in %v", rule.Attribute, value, describe(element)))
+		case configuration.RewriteActionReplaceText:
+			text := element.Text()
+			newText := value
+			if rule.Search != "" {
+				newText = strings.ReplaceAll(text, rule.Search, value)
+			}
+			if newText != text {
+				setText(element, newText)
+				rewrites = append(rewrites, fmt.Sprintf("text '%v' -> '%v' in %v", text, newText, describe(element)))
+			}
+		case configuration.RewriteActionRemoveElement:
+			if element.Parent() != nil {
+				element.Parent().RemoveChild(element)
+				rewrites = append(rewrites, fmt.Sprintf("removed %v", describe(element)))
+			}
+		case configuration.RewriteActionPlaceholder:
+			count := 0
+			for i, attr := range element.Attr {
+				if rule.Attribute != "" && fullName(attr.Space, attr.Key) != rule.Attribute {
+					continue
+				}
+				if strings.Contains(attr.Value, rule.Search) {
+					count = count + strings.Count(attr.Value, rule.Search)
+					element.Attr[i].Value = strings.ReplaceAll(attr.Value, rule.Search, value)
+				}
+			}
+			if text := element.Text(); rule.Attribute == "" && strings.Contains(text, rule.Search) {
+				count = count + strings.Count(text, rule.Search)
+				setText(element, strings.ReplaceAll(text, rule.Search, value))
+			}
+			if count > 0 {
+				rewrites = append(rewrites, fmt.Sprintf("replaced %v placeholder(s) '%v' with '%v' in %v", count, rule.Search, value, describe(element)))
+			}
+		}
+	}
+	return rewrites, nil
+}
+
+func getPath(rule configuration.RewriteRule) string {
+	if rule.Path == "" {
+		return "//*"
+	}
+	return rule.Path
+}
+
+// etree.CompilePath may panic on malformed paths
+func compilePath(path string) (result etree.Path, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid path '%v': %v", path, r)
+		}
+	}()
+	return etree.CompilePath(path)
+}
+
+func getValue(rule configuration.RewriteRule) string {
+	if rule.Env != "" {
+		if value, ok := os.LookupEnv(rule.Env); ok {
+			return value
+		}
+	}
+	return rule.Value
+}
+
+func describe(element *etree.Element) string {
+	if id := element.SelectAttrValue("id", ""); id != "" {
+		return id
+	}
+	return fullName(element.Space, element.Tag)
+}
+
+func fullName(space string, name string) string {
+	if space == "" {
+		return name
+	}
+	return space + ":" + name
+}
+
+// etree.Element.SetText expects the text to be the first child; elements without text get the text inserted as first child
+func setText(element *etree.Element, text string) {
+	if len(element.Child) > 0 {
+		if cd, ok := element.Child[0].(*etree.CharData); ok {
+			cd.Data = text
+			return
+		}
+	}
+	cd := element.CreateCharData(text)
+	copy(element.Child[1:], element.Child[:len(element.Child)-1])
+	element.Child[0] = cd
+}
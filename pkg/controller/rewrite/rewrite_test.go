@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rewrite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+)
+
+const testBpmn = `<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="Definitions_1">
+<bpmn:process id="Process_1" isExecutable="true">
+<bpmn:serviceTask id="Task_1" camunda:type="external" camunda:topic="optimistic"><bpmn:extensionElements><camunda:inputOutput><camunda:inputParameter name="url">{{SITE}}/api</camunda:inputParameter><camunda:inputParameter name="empty"/></camunda:inputOutput></bpmn:extensionElements></bpmn:serviceTask>
+<bpmn:serviceTask id="Task_2" camunda:type="external" camunda:topic="pessimistic"/>
+<bpmn:textAnnotation id="Note_1"><bpmn:text>remove me</bpmn:text></bpmn:textAnnotation>
+</bpmn:process>
+</bpmn:definitions>`
+
+func TestApply(t *testing.T) {
+	t.Run("no rules", func(t *testing.T) {
+		result, rewrites, err := Apply(testBpmn, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result != testBpmn || len(rewrites) != 0 {
+			t.Error(result, rewrites)
+		}
+	})
+
+	t.Run("set attribute", testApply([]configuration.RewriteRule{
+		{Path: "//bpmn:serviceTask[@camunda:topic='optimistic']", Action: configuration.RewriteActionSetAttribute, Attribute: "camunda:asyncBefore", Value: "true"},
+	}, 1, `<bpmn:serviceTask id="Task_1" camunda:type="external" camunda:topic="optimistic" camunda:asyncBefore="true">`, `<bpmn:serviceTask id="Task_2" camunda:type="external" camunda:topic="pessimistic"/>`))
+
+	t.Run("replace text", testApply([]configuration.RewriteRule{
+		{Path: "//camunda:inputParameter[@name='url']", Action: configuration.RewriteActionReplaceText, Search: "/api", Value: "/v2"},
+		{Path: "//camunda:inputParameter[@name='empty']", Action: configuration.RewriteActionReplaceText, Value: "filled"},
+	}, 2, `<camunda:inputParameter name="url">{{SITE}}/v2</camunda:inputParameter>`, `<camunda:inputParameter name="empty">filled</camunda:inputParameter>`))
+
+	t.Run("remove element", func(t *testing.T) {
+		rules := []configuration.RewriteRule{
+			{Path: "//bpmn:textAnnotation", Action: configuration.RewriteActionRemoveElement},
+		}
+		testApply(rules, 1, `<bpmn:serviceTask id="Task_2" camunda:type="external" camunda:topic="pessimistic"/>`)(t)
+		result, _, err := Apply(testBpmn, rules)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if strings.Contains(result, "textAnnotation") || strings.Contains(result, "remove me") {
+			t.Error("element not removed\n", result)
+		}
+	})
+
+	t.Run("placeholder", testApply([]configuration.RewriteRule{
+		{Action: configuration.RewriteActionPlaceholder, Search: "{{SITE}}", Value: "http://site"},
+	}, 1, `<camunda:inputParameter name="url">http://site/api</camunda:inputParameter>`))
+
+	t.Run("placeholder env", func(t *testing.T) {
+		t.Setenv("REWRITE_TEST_SITE", "http://env-site")
+		testApply([]configuration.RewriteRule{
+			{Action: configuration.RewriteActionPlaceholder, Search: "{{SITE}}", Value: "http://site", Env: "REWRITE_TEST_SITE"},
+		}, 1, `<camunda:inputParameter name="url">http://env-site/api</camunda:inputParameter>`)(t)
+	})
+
+	t.Run("in order", testApply([]configuration.RewriteRule{
+		{Path: "//bpmn:serviceTask[@camunda:topic='optimistic']", Action: configuration.RewriteActionSetAttribute, Attribute: "camunda:topic", Value: "{{TOPIC}}"},
+		{Path: "//bpmn:serviceTask", Action: configuration.RewriteActionPlaceholder, Attribute: "camunda:topic", Search: "{{TOPIC}}", Value: "pessimistic"},
+	}, 2, `<bpmn:serviceTask id="Task_1" camunda:type="external" camunda:topic="pessimistic">`))
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, rule := range []configuration.RewriteRule{
+			{Action: "unknown"},
+			{Path: "//bpmn:serviceTask[", Action: configuration.RewriteActionRemoveElement},
+			{Action: configuration.RewriteActionSetAttribute},
+			{Action: configuration.RewriteActionPlaceholder},
+		} {
+			if err := Validate([]configuration.RewriteRule{rule}); err == nil {
+				t.Error("expected validation error", rule)
+			}
+			if _, _, err := Apply(testBpmn, []configuration.RewriteRule{rule}); err == nil {
+				t.Error("expected apply error", rule)
+			}
+		}
+	})
+}
+
+func testApply(rules []configuration.RewriteRule, expectedRewrites int, expectedContent ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		if err := Validate(rules); err != nil {
+			t.Error(err)
+			return
+		}
+		result, rewrites, err := Apply(testBpmn, rules)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(rewrites) != expectedRewrites {
+			t.Error(rewrites)
+		}
+		for _, expected := range expectedContent {
+			if !strings.Contains(result, expected) {
+				t.Error(expected, "\n", result)
+			}
+		}
+	}
+}
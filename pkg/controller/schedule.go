@@ -0,0 +1,186 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"text/template"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/cron"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+)
+
+func (this *Controller) schedulerEnabled() bool {
+	return this.config.ScheduleCheckInterval != "" && this.config.ScheduleCheckInterval != "-"
+}
+
+// startScheduler periodically starts processes of deployments with due schedules
+// works without connection to the cloud; the last run of each schedule is stored in the metadata storage
+func (this *Controller) startScheduler(ctx context.Context) {
+	if !this.schedulerEnabled() || this.metadata.IsPlaceholder() {
+		return
+	}
+	interval, err := time.ParseDuration(this.config.ScheduleCheckInterval)
+	if err != nil {
+		log.Println("WARNING: unable to parse schedule check interval", this.config.ScheduleCheckInterval, err)
+		return
+	}
+	location := time.Local
+	if this.config.ScheduleLocation != "" {
+		location, err = time.LoadLocation(this.config.ScheduleLocation)
+		if err != nil {
+			log.Println("WARNING: unable to load schedule location", this.config.ScheduleLocation, err)
+			return
+		}
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case t := <-ticker.C:
+				for _, execution := range this.runDueSchedules(t.In(location)) {
+					err := this.backend.SendScheduleExecution(execution)
+					if err != nil {
+						log.Println("WARNING: unable to send schedule execution", err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+func (this *Controller) runDueSchedules(now time.Time) (executions []model.ScheduleExecution) {
+	list, err := this.metadata.List()
+	if err != nil {
+		log.Println("ERROR: unable to list deployment metadata for schedules", err)
+		return nil
+	}
+	for _, md := range list {
+		for _, schedule := range md.DeploymentModel.Schedules {
+			last, ok := md.ScheduleLastRuns[schedule.Id]
+			if !ok {
+				//first check of a new schedule: count from now
+				err = this.setScheduleLastRun(md.CamundaDeploymentId, schedule.Id, now)
+				if err != nil {
+					log.Println("ERROR: unable to store schedule last run", md.CamundaDeploymentId, schedule.Id, err)
+				}
+				continue
+			}
+			cronSchedule, err := cron.Parse(schedule.Cron)
+			if err != nil {
+				log.Println("WARNING: invalid schedule", md.CamundaDeploymentId, schedule.Id, err)
+				continue
+			}
+			next := cronSchedule.Next(last.In(now.Location()))
+			if next.IsZero() || next.After(now) {
+				continue
+			}
+			//missed runs (e.g. while the client was stopped) are executed once
+			//the last run is stored before the start to not start twice
+			err = this.setScheduleLastRun(md.CamundaDeploymentId, schedule.Id, now)
+			if err != nil {
+				log.Println("ERROR: unable to store schedule last run", md.CamundaDeploymentId, schedule.Id, err)
+				continue
+			}
+			executions = append(executions, this.runSchedule(md, schedule, now))
+		}
+	}
+	return executions
+}
+
+func (this *Controller) runSchedule(md metadata.Metadata, schedule model.Schedule, now time.Time) (result model.ScheduleExecution) {
+	result = model.ScheduleExecution{
+		DeploymentId:        md.DeploymentModel.Id,
+		CamundaDeploymentId: md.CamundaDeploymentId,
+		ScheduleId:          schedule.Id,
+		Time:                now,
+	}
+	businessKey, err := getScheduleBusinessKey(md, schedule, now)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.BusinessKey = businessKey
+	err = this.autoStartDeployment(md.CamundaDeploymentId, businessKey, model.MergeTypedVariables(schedule.Parameter, schedule.TypedParameter))
+	if err != nil {
+		log.Println("ERROR: unable to start scheduled process", md.CamundaDeploymentId, schedule.Id, err)
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func getScheduleBusinessKey(md metadata.Metadata, schedule model.Schedule, now time.Time) (string, error) {
+	if schedule.BusinessKey == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("").Parse(schedule.BusinessKey)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	err = tmpl.Execute(buf, struct {
+		DeploymentId string
+		ScheduleId   string
+		Time         time.Time
+	}{
+		DeploymentId: md.DeploymentModel.Id,
+		ScheduleId:   schedule.Id,
+		Time:         now,
+	})
+	return buf.String(), err
+}
+
+func (this *Controller) setScheduleLastRun(camundaDeploymentId string, scheduleId string, t time.Time) error {
+	return this.updateMetadata(camundaDeploymentId, func(md *metadata.Metadata) {
+		if md.ScheduleLastRuns == nil {
+			md.ScheduleLastRuns = map[string]time.Time{}
+		}
+		md.ScheduleLastRuns[scheduleId] = t
+	})
+}
+
+// updateMetadata reads, modifies and stores the metadata of the deployment
+// all metadata writes use metadataMux (see storeMetadata and removeMetadata) to not overwrite each other
+func (this *Controller) updateMetadata(camundaDeploymentId string, update func(md *metadata.Metadata)) error {
+	this.metadataMux.Lock()
+	defer this.metadataMux.Unlock()
+	md, err := this.metadata.Read(camundaDeploymentId)
+	if err != nil {
+		return err
+	}
+	update(&md)
+	return this.metadata.Store(md)
+}
+
+func (this *Controller) storeMetadata(md metadata.Metadata) error {
+	this.metadataMux.Lock()
+	defer this.metadataMux.Unlock()
+	return this.metadata.Store(md)
+}
+
+func (this *Controller) removeMetadata(camundaDeploymentId string) error {
+	this.metadataMux.Lock()
+	defer this.metadataMux.Unlock()
+	return this.metadata.Remove(camundaDeploymentId)
+}
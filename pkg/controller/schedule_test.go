@@ -0,0 +1,143 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+)
+
+func TestRunDueSchedules(t *testing.T) {
+	mux := sync.Mutex{}
+	started := []map[string]interface{}{}
+	fake := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/engine-rest/process-definition" {
+			json.NewEncoder(writer).Encode(camundamodel.ProcessDefinitions{{Id: "def1"}})
+			return
+		}
+		if strings.HasSuffix(request.URL.Path, "/submit-form") {
+			msg := map[string]interface{}{}
+			json.NewDecoder(request.Body).Decode(&msg)
+			mux.Lock()
+			started = append(started, msg)
+			mux.Unlock()
+			return
+		}
+		http.NotFound(writer, request)
+	})
+
+	ctrl := newTestController(t, configuration.Config{}, fake, metadata.Metadata{
+		CamundaDeploymentId: "c1",
+		DeploymentModel: model.FogDeploymentMessage{
+			Deployment: deploymentmodel.Deployment{Id: "d1"},
+			Schedules: []model.Schedule{{
+				Id:          "hourly",
+				Cron:        "0 * * * *",
+				BusinessKey: `{{.DeploymentId}}-{{.Time.Format "15:04"}}`,
+				Parameter:   map[string]interface{}{"foo": "bar"},
+				TypedParameter: map[string]camundamodel.Variable{
+					"config": {Type: "Json", Value: map[string]interface{}{"a": 1}},
+				},
+			}},
+		},
+	})
+
+	start := time.Date(2026, 1, 14, 10, 30, 0, 0, time.UTC)
+
+	t.Run("init", func(t *testing.T) {
+		executions := ctrl.runDueSchedules(start)
+		if len(executions) != 0 {
+			t.Error(executions)
+		}
+		md, err := ctrl.metadata.Read("c1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !md.ScheduleLastRuns["hourly"].Equal(start) {
+			t.Error(md.ScheduleLastRuns)
+		}
+	})
+
+	t.Run("not due", func(t *testing.T) {
+		executions := ctrl.runDueSchedules(start.Add(20 * time.Minute))
+		if len(executions) != 0 {
+			t.Error(executions)
+		}
+	})
+
+	t.Run("due", func(t *testing.T) {
+		executions := ctrl.runDueSchedules(start.Add(30 * time.Minute))
+		if len(executions) != 1 {
+			t.Error(executions)
+			return
+		}
+		if executions[0].Error != "" || executions[0].BusinessKey != "d1-11:00" || executions[0].ScheduleId != "hourly" {
+			t.Errorf("%#v", executions[0])
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		if len(started) != 1 || started[0]["businessKey"] != "d1-11:00" {
+			t.Error(started)
+			return
+		}
+		variables, _ := started[0]["variables"].(map[string]interface{})
+		config, _ := variables["config"].(map[string]interface{})
+		if config["type"] != "Json" || config["value"] != `{"a":1}` {
+			t.Error(variables)
+		}
+	})
+
+	t.Run("missed runs are executed once", func(t *testing.T) {
+		executions := ctrl.runDueSchedules(start.Add(5 * time.Hour))
+		if len(executions) != 1 {
+			t.Error(executions)
+		}
+		executions = ctrl.runDueSchedules(start.Add(5*time.Hour + time.Minute))
+		if len(executions) != 0 {
+			t.Error(executions)
+		}
+	})
+}
+
+func TestValidateSchedules(t *testing.T) {
+	errs := ValidateSchedules([]model.Schedule{
+		{Id: "ok", Cron: "@daily", BusinessKey: "{{.ScheduleId}}"},
+		{Id: "", Cron: "@daily"},
+		{Id: "ok", Cron: "@daily"},
+		{Id: "cron", Cron: "* * *"},
+		{Id: "template", Cron: "@daily", BusinessKey: "{{.ScheduleId"},
+	})
+	if len(errs) != 4 {
+		t.Error(errs)
+	}
+	for _, e := range errs {
+		if e.Rule != model.ValidationRuleSchedule {
+			t.Error(e)
+		}
+	}
+}
@@ -92,7 +92,8 @@ func TestSendCurrentIncidents(t *testing.T) {
 		t.Error(err)
 		return
 	}
-	ctrl.events, err = events.StartApi(ctx, config)
+	ctrl.command = ctrl.backend.WithSource(model.StateSourceCommand)
+	ctrl.events, err = events.StartApi(ctx, config, ctrl)
 	if err != nil {
 		t.Error(err)
 		return
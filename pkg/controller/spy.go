@@ -45,6 +45,36 @@ func (this *Controller) spyOnCamundaDb(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if this.config.SyncExternalTasks {
+		err = this.spyOn(ctx, "external_task", "ACT_RU_EXT_TASK", this.NotifyExternalTaskUpdate, this.NotifyExternalTaskDelete)
+		if err != nil {
+			return err
+		}
+	}
+	if this.config.SyncJobs {
+		err = this.spyOn(ctx, "job", "ACT_RU_JOB", this.NotifyJobUpdate, this.NotifyJobDelete)
+		if err != nil {
+			return err
+		}
+	}
+	if this.config.SyncUserTasks {
+		err = this.spyOn(ctx, "user_task", "ACT_RU_TASK", this.NotifyUserTaskUpdate, this.NotifyUserTaskDelete)
+		if err != nil {
+			return err
+		}
+	}
+	if this.config.SyncProcessVariables {
+		err = this.spyOn(ctx, "variable", pglistener.VariableTable, this.NotifyVariableUpdate, this.NotifyVariableDelete)
+		if err != nil {
+			return err
+		}
+	}
+	if this.config.SyncActivityInstances {
+		err = this.spyOn(ctx, "activity_instance", "ACT_HI_ACTINST", this.NotifyActivityInstanceUpdate, this.NotifyActivityInstanceDelete)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
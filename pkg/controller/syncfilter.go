@@ -0,0 +1,183 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func ValidateSyncFilters(filters []configuration.SyncFilter) error {
+	for i, filter := range filters {
+		if _, err := path.Match(filter.Name, ""); err != nil {
+			return fmt.Errorf("invalid sync filter %v: invalid name pattern '%v'", i, filter.Name)
+		}
+	}
+	return nil
+}
+
+func (this *Controller) syncFilterEnabled() bool {
+	return len(this.config.SyncInclude) > 0 || len(this.config.SyncExclude) > 0
+}
+
+// isSyncedDefinition applies sync_include and sync_exclude to the deployment of the process definition
+// unresolvable definitions (e.g. of removed deployments) are not synced; the full sync removes their states by the known ids
+func (this *Controller) isSyncedDefinition(processDefinitionId string) bool {
+	if !this.syncFilterEnabled() {
+		return true
+	}
+	return this.isSyncedDeployment(this.getDeploymentIdOfDefinition(processDefinitionId))
+}
+
+// isSyncedDeployment applies sync_include and sync_exclude to the camunda deployment
+// unresolvable deployments (e.g. removed deployments) are not synced
+func (this *Controller) isSyncedDeployment(camundaDeploymentId string) bool {
+	if !this.syncFilterEnabled() {
+		return true
+	}
+	if camundaDeploymentId == "" {
+		return false
+	}
+	this.syncFilterMux.Lock()
+	synced, ok := this.syncFilterCache[camundaDeploymentId]
+	this.syncFilterMux.Unlock()
+	if ok {
+		return synced
+	}
+	deployment, err := this.camunda.GetDeployment(camundaDeploymentId, UserId)
+	if err != nil {
+		log.Println("WARNING: unable to get deployment for sync filter", camundaDeploymentId, err)
+		return false
+	}
+	return this.isSyncedCamundaDeployment(deployment)
+}
+
+// isSyncedCamundaDeployment applies sync_include and sync_exclude to the deployment and caches the result
+// labels are unknown until the metadata is stored after the camunda deployment (or without metadata storage)
+// unknown labels match no include but every exclude with labels, and the result is not cached
+// see sendLabelFilteredDeploymentStates for the states held back until the metadata is stored
+func (this *Controller) isSyncedCamundaDeployment(deployment camundamodel.Deployment) bool {
+	if !this.syncFilterEnabled() {
+		return true
+	}
+	labelsKnown := true
+	var labels map[string]string
+	if this.syncFilterUsesLabels() {
+		labelsKnown = false
+		if this.metadata != nil && !this.metadata.IsPlaceholder() {
+			md, err := this.metadata.Read(deployment.Id)
+			if err == nil {
+				labels = md.DeploymentModel.Labels
+				labelsKnown = true
+			}
+		}
+	}
+	synced := len(this.config.SyncInclude) == 0
+	for _, filter := range this.config.SyncInclude {
+		if matchesSyncFilter(filter, deployment, labels) {
+			synced = true
+			break
+		}
+	}
+	for _, filter := range this.config.SyncExclude {
+		if !labelsKnown {
+			filter.Labels = nil
+		}
+		if matchesSyncFilter(filter, deployment, labels) {
+			synced = false
+			break
+		}
+	}
+	if labelsKnown {
+		this.syncFilterMux.Lock()
+		if this.syncFilterCache == nil {
+			this.syncFilterCache = map[string]bool{}
+		}
+		this.syncFilterCache[deployment.Id] = synced
+		this.syncFilterMux.Unlock()
+	}
+	return synced
+}
+
+func (this *Controller) syncFilterUsesLabels() bool {
+	for _, filter := range append(append([]configuration.SyncFilter{}, this.config.SyncInclude...), this.config.SyncExclude...) {
+		if len(filter.Labels) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sendLabelFilteredDeploymentStates sends the deployment and process definition states of a new deployment
+// these states are held back by label sync filters because they are notified before the metadata with the labels is stored
+func (this *Controller) sendLabelFilteredDeploymentStates(camundaDeploymentId string) {
+	if !this.syncFilterUsesLabels() || !this.isSyncedDeployment(camundaDeploymentId) {
+		return
+	}
+	deployment, err := this.camunda.GetDeployment(camundaDeploymentId, UserId)
+	if err != nil {
+		log.Println("WARNING: unable to get deployment for label filtered states", camundaDeploymentId, err)
+		return
+	}
+	err = this.backend.SendDeploymentUpdate(deployment)
+	if err != nil {
+		log.Println("ERROR: unable to send label filtered deployment update", camundaDeploymentId, err)
+		return
+	}
+	definitions, err := this.camunda.GetDefinitionByDeploymentVid(camundaDeploymentId, UserId)
+	if err != nil {
+		log.Println("WARNING: unable to get process definitions for label filtered states", camundaDeploymentId, err)
+		return
+	}
+	for _, definition := range definitions {
+		err = this.backend.SendProcessDefinitionUpdate(definition)
+		if err != nil {
+			log.Println("ERROR: unable to send label filtered process definition update", definition.Id, err)
+			return
+		}
+	}
+}
+
+func (this *Controller) invalidateSyncFilter(camundaDeploymentId string) {
+	this.syncFilterMux.Lock()
+	defer this.syncFilterMux.Unlock()
+	delete(this.syncFilterCache, camundaDeploymentId)
+}
+
+func matchesSyncFilter(filter configuration.SyncFilter, deployment camundamodel.Deployment, labels map[string]string) bool {
+	if filter.Name != "" {
+		if match, _ := path.Match(filter.Name, deployment.Name); !match {
+			return false
+		}
+	}
+	if filter.Source != "" && filter.Source != deployment.Source {
+		return false
+	}
+	if filter.Tenant != "" && filter.Tenant != deployment.TenantId {
+		return false
+	}
+	for key, value := range filter.Labels {
+		if actual, ok := labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+func TestSyncFilter(t *testing.T) {
+	config := configuration.Config{
+		SyncInclude: []configuration.SyncFilter{
+			{Name: "lab-*"},
+			{Labels: map[string]string{"site": "lab"}},
+		},
+		SyncExclude: []configuration.SyncFilter{
+			{Source: "local-test"},
+		},
+	}
+	err := ValidateSyncFilters(append(config.SyncInclude, config.SyncExclude...))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ctrl := newTestController(t, config, nil, metadata.Metadata{
+		CamundaDeploymentId: "labeled",
+		DeploymentModel:     model.FogDeploymentMessage{Labels: map[string]string{"site": "lab"}},
+	})
+
+	for _, test := range []struct {
+		deployment camundamodel.Deployment
+		expected   bool
+	}{
+		{deployment: camundamodel.Deployment{Id: "a", Name: "lab-heating"}, expected: true},
+		{deployment: camundamodel.Deployment{Id: "b", Name: "office-heating"}, expected: false},
+		{deployment: camundamodel.Deployment{Id: "c", Name: "lab-test", Source: "local-test"}, expected: false},
+		{deployment: camundamodel.Deployment{Id: "labeled", Name: "office-light"}, expected: true},
+	} {
+		if synced := ctrl.isSyncedCamundaDeployment(test.deployment); synced != test.expected {
+			t.Error(test.deployment.Id, synced)
+		}
+	}
+
+	//deployments without metadata are not cached while label filters are used
+	if synced, ok := ctrl.syncFilterCache["labeled"]; !ok || !synced {
+		t.Error(ctrl.syncFilterCache)
+	}
+	if _, ok := ctrl.syncFilterCache["b"]; ok {
+		t.Error(ctrl.syncFilterCache)
+	}
+
+	ctrl.syncFilterCache["b"] = false
+	ctrl.definitions = map[string]camundamodel.ProcessDefinition{
+		"def-b": {Id: "def-b", DeploymentId: "b"},
+	}
+	if ctrl.isSyncedDefinition("def-b") {
+		t.Error("expected excluded definition")
+	}
+	ctrl.invalidateSyncFilter("b")
+	if _, ok := ctrl.syncFilterCache["b"]; ok {
+		t.Error(ctrl.syncFilterCache)
+	}
+
+	if ValidateSyncFilters([]configuration.SyncFilter{{Name: "["}}) == nil {
+		t.Error("expected invalid name pattern")
+	}
+}
+
+func TestSyncFilterUnknownLabels(t *testing.T) {
+	config := configuration.Config{
+		SyncExclude: []configuration.SyncFilter{
+			{Labels: map[string]string{"secret": "true"}},
+		},
+	}
+	ctrl := newTestController(t, config, http.NotFoundHandler(),
+		metadata.Metadata{CamundaDeploymentId: "known", DeploymentModel: model.FogDeploymentMessage{Labels: map[string]string{"site": "lab"}}},
+		metadata.Metadata{CamundaDeploymentId: "secret", DeploymentModel: model.FogDeploymentMessage{Labels: map[string]string{"secret": "true"}}},
+	)
+
+	for id, expected := range map[string]bool{"known": true, "secret": false, "unknown": false} {
+		if synced := ctrl.isSyncedCamundaDeployment(camundamodel.Deployment{Id: id}); synced != expected {
+			t.Error(id, synced)
+		}
+	}
+	if _, ok := ctrl.syncFilterCache["unknown"]; ok {
+		t.Error("unexpected cached result for unknown labels", ctrl.syncFilterCache)
+	}
+
+	ctrl.config.SyncInclude = []configuration.SyncFilter{{Name: "*"}}
+	if ctrl.isSyncedCamundaDeployment(camundamodel.Deployment{Id: "unknown", Name: "included"}) {
+		t.Error("expected unknown labels to match the label exclude")
+	}
+
+	if ctrl.isSyncedDeployment("") || ctrl.isSyncedDeployment("unresolvable") {
+		t.Error("expected unresolvable deployments to be excluded")
+	}
+	if ctrl.isSyncedDefinition("") || ctrl.isSyncedDefinition("unresolvable") {
+		t.Error("expected unresolvable definitions to be excluded")
+	}
+
+	report, _, _ := ctrl.getDeploymentDriftReport([]string{"known", "unknown"}, nil)
+	if !reflect.DeepEqual(report.MissingMetadata, []string{"known"}) {
+		t.Error(report.MissingMetadata)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// {"id_":"6b84bb0a-750c-11eb-b54c-0242ac110006","rev_":1,"execution_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_def_id_":"ExampleId:1:686e7a53-750c-11eb-b54c-0242ac110006","case_execution_id_":null,"case_inst_id_":null,"case_def_id_":null,"name_":"Confirm","parent_task_id_":null,"description_":null,"task_def_key_":"UserTask_1","owner_":null,"assignee_":"operator","delegation_":null,"priority_":50,"create_time_":"2021-02-22T12:49:36.886","last_updated_":null,"due_date_":null,"follow_up_date_":null,"suspension_state_":1,"tenant_id_":"user"}
+type UserTaskInPg struct {
+	Id                  string  `json:"id_"`
+	ExecutionId         string  `json:"execution_id_"`
+	ProcessInstanceId   string  `json:"proc_inst_id_"`
+	ProcessDefinitionId string  `json:"proc_def_id_"`
+	Name                string  `json:"name_"`
+	Description         string  `json:"description_"`
+	TaskDefinitionKey   string  `json:"task_def_key_"`
+	Owner               string  `json:"owner_"`
+	Assignee            string  `json:"assignee_"`
+	Priority            float64 `json:"priority_"`
+	CreateTime          string  `json:"create_time_"`
+	DueDate             string  `json:"due_date_"`
+	FollowUpDate        string  `json:"follow_up_date_"`
+	SuspensionState     int     `json:"suspension_state_"` //1: active, 2: suspended
+	TenantId            string  `json:"tenant_id_"`
+}
+
+func (this *Controller) NotifyUserTaskUpdate(extra string) {
+	element := UserTaskInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal user task in NotifyUserTaskUpdate(): ", err)
+		return
+	}
+	if !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	task := camundamodel.Task{
+		Id:                  element.Id,
+		Name:                element.Name,
+		Assignee:            element.Assignee,
+		Owner:               element.Owner,
+		Created:             element.CreateTime,
+		Due:                 element.DueDate,
+		FollowUp:            element.FollowUpDate,
+		Description:         element.Description,
+		ExecutionId:         element.ExecutionId,
+		ProcessInstanceId:   element.ProcessInstanceId,
+		ProcessDefinitionId: element.ProcessDefinitionId,
+		TaskDefinitionKey:   element.TaskDefinitionKey,
+		Priority:            element.Priority,
+		Suspended:           element.SuspensionState == 2,
+		TenantId:            element.TenantId,
+	}
+	task.FormVariables, err = this.getSyncedTaskFormVariables(element.Id, element.ProcessDefinitionId)
+	if err != nil {
+		//the task may already be completed
+		log.Println("WARNING: unable to get user task form variables in NotifyUserTaskUpdate(): ", err)
+	}
+	err = this.backend.SendUserTaskUpdate(task)
+	if err != nil {
+		log.Println("ERROR: unable to send user task update in NotifyUserTaskUpdate(): ", err)
+		return
+	}
+}
+
+func (this *Controller) NotifyUserTaskDelete(extra string) {
+	element := UserTaskInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal user task in NotifyUserTaskDelete(): ", err)
+		return
+	}
+	if !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	err = this.backend.SendUserTaskDelete(element.Id)
+	if err != nil {
+		log.Println("ERROR: unable to send user task delete in NotifyUserTaskDelete(): ", err)
+		return
+	}
+}
+
+func (this *Controller) SendCurrentUserTasks() error {
+	if !this.config.SyncUserTasks {
+		return nil
+	}
+	tasks, err := this.camunda.GetTaskList(UserId)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, task := range tasks {
+		if !this.isSyncedDefinition(task.ProcessDefinitionId) {
+			continue
+		}
+		ids = append(ids, task.Id)
+		task.FormVariables, err = this.getSyncedTaskFormVariables(task.Id, task.ProcessDefinitionId)
+		if err != nil {
+			log.Println("WARNING: unable to get user task form variables in SendCurrentUserTasks(): ", err)
+		}
+		err = this.fullSync.SendUserTaskUpdate(task)
+		if err != nil {
+			return err
+		}
+	}
+	return this.fullSync.SendUserTaskKnownIds(ids)
+}
+
+// getSyncedTaskFormVariables returns the form variables of the task allowed by the synced_variables of its deployment
+// camunda returns all visible process variables for tasks without declared form fields; values are redacted like variable updates
+// tasks of deployments without synced_variables send no form variables and skip the camunda request
+func (this *Controller) getSyncedTaskFormVariables(taskId string, processDefinitionId string) (map[string]camundamodel.Variable, error) {
+	definition, err := this.getProcessDefinition(processDefinitionId)
+	if err != nil {
+		return nil, err
+	}
+	if len(this.getSyncedVariables(definition.Key)) == 0 {
+		return nil, nil
+	}
+	variables, err := this.camunda.GetTaskFormVariables(taskId, UserId)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]camundamodel.Variable{}
+	for name, variable := range variables {
+		if !this.variableIsSynced(definition.Key, name) {
+			continue
+		}
+		variable.Value = redactVariable(this.config.VariableRedactionRules, name, variable.Value)
+		result[name] = variable
+	}
+	return result, nil
+}
+
+func (this *Controller) CompleteUserTask(taskId string, variables map[string]interface{}) error {
+	if taskId == "" {
+		return errors.New("missing task id")
+	}
+	variables, err := NormalizeVariables(variables)
+	if err != nil {
+		return err
+	}
+	return this.camunda.CompleteTask(taskId, UserId, variables)
+}
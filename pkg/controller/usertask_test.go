@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+)
+
+func TestCompleteUserTask(t *testing.T) {
+	var path string
+	var body map[string]map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		path = request.URL.Path
+		json.NewDecoder(request.Body).Decode(&body)
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	config := configuration.Config{}
+	ctrl := &Controller{config: config, camunda: camunda.New(config, shards.Shards(server.URL))}
+
+	err := ctrl.CompleteUserTask("", nil)
+	if err == nil {
+		t.Error("expected error for missing task id")
+	}
+
+	err = ctrl.CompleteUserTask("t1", map[string]interface{}{"confirmed": true})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if path != "/engine-rest/task/t1/complete" {
+		t.Error(path)
+	}
+	if body["variables"]["confirmed"]["value"] != true {
+		t.Error(body)
+	}
+}
+
+func TestGetSyncedTaskFormVariables(t *testing.T) {
+	requests := 0
+	fake := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		json.NewEncoder(writer).Encode(map[string]camundamodel.Variable{
+			"energy":   {Value: 42.0, Type: "Double"},
+			"note":     {Value: "secret 1234", Type: "String"},
+			"password": {Value: "secret", Type: "String"},
+		})
+	})
+	config := configuration.Config{
+		VariableRedactionRules: []configuration.RedactionRule{{Variable: "note", Pattern: "[0-9]+"}},
+	}
+	ctrl := newTestController(t, config, fake, metadata.Metadata{
+		CamundaDeploymentId: "c1",
+		DeploymentModel: model.FogDeploymentMessage{
+			Deployment:      deploymentmodel.Deployment{Id: "d1"},
+			SyncedVariables: []string{"energy", "note"},
+		},
+	})
+	ctrl.cacheProcessDefinition(camundamodel.ProcessDefinition{Id: "def1", Key: getProcessDefinitionKey("d1")})
+	ctrl.cacheProcessDefinition(camundamodel.ProcessDefinition{Id: "def2", Key: getProcessDefinitionKey("d2")})
+
+	result, err := ctrl.getSyncedTaskFormVariables("t1", "def1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != 2 || result["energy"].Value != 42.0 || result["note"].Value != "secret ***" {
+		t.Error(result)
+	}
+
+	result, err = ctrl.getSyncedTaskFormVariables("t2", "def2")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result != nil {
+		t.Error(result)
+	}
+	if requests != 1 {
+		t.Error("expected no form variable request for deployments without synced variables", requests)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/cron"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/etree"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// ValidateDeploymentXml checks the bpmn that would be deployed to camunda
+// if knownTaskTopics is empty, task topics are not checked
+func ValidateDeploymentXml(xmlStr string, knownTaskTopics []string) (result model.ValidationErrors) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s: %s", r, debug.Stack())
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleXml, Message: fmt.Sprint("Recovered Error: ", r)})
+		}
+	}()
+	if xmlStr == "" {
+		return model.ValidationErrors{{Rule: model.ValidationRuleXml, Message: "empty xml"}}
+	}
+	doc := etree.NewDocument()
+	err := doc.ReadFromString(xmlStr)
+	if err != nil {
+		return model.ValidationErrors{{Rule: model.ValidationRuleXml, Message: err.Error()}}
+	}
+	err = xml.Unmarshal([]byte(xmlStr), new(interface{}))
+	if err != nil {
+		return model.ValidationErrors{{Rule: model.ValidationRuleXml, Message: err.Error()}}
+	}
+	result = append(result, validateExecutable(doc)...)
+	result = append(result, validateUniqueIds(doc)...)
+	result = append(result, validateTaskTopics(doc, knownTaskTopics)...)
+	result = append(result, validateReferences(doc, model.ValidationRuleMessageRef, "messageRef", "//bpmn:message")...)
+	result = append(result, validateReferences(doc, model.ValidationRuleSignalRef, "signalRef", "//bpmn:signal")...)
+	return result
+}
+
+func validateExecutable(doc *etree.Document) (result model.ValidationErrors) {
+	processes := doc.FindElements("//bpmn:process")
+	if len(processes) == 0 {
+		return model.ValidationErrors{{Rule: model.ValidationRuleExecutable, Message: "no process found"}}
+	}
+	for _, process := range processes {
+		if process.SelectAttrValue("isExecutable", "false") == "true" {
+			return nil
+		}
+	}
+	return model.ValidationErrors{{Rule: model.ValidationRuleExecutable, Message: "no executable process found"}}
+}
+
+func validateUniqueIds(doc *etree.Document) (result model.ValidationErrors) {
+	known := map[string]bool{}
+	for _, element := range doc.FindElements("//*[@id]") {
+		id := element.SelectAttrValue("id", "")
+		if known[id] {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleUniqueId, ElementId: id, Message: "duplicate id"})
+		}
+		known[id] = true
+	}
+	return result
+}
+
+func validateTaskTopics(doc *etree.Document, knownTaskTopics []string) (result model.ValidationErrors) {
+	if len(knownTaskTopics) == 0 {
+		return nil
+	}
+	for _, element := range doc.FindElements("//*[@camunda:topic]") {
+		topic := element.SelectAttrValue("camunda:topic", "")
+		if !slices.Contains(knownTaskTopics, topic) {
+			result = append(result, model.ValidationError{
+				Rule:      model.ValidationRuleTaskTopic,
+				ElementId: element.SelectAttrValue("id", ""),
+				Message:   "unknown task topic '" + topic + "'",
+			})
+		}
+	}
+	return result
+}
+
+func validateReferences(doc *etree.Document, rule string, refAttr string, declarationPath string) (result model.ValidationErrors) {
+	declared := map[string]bool{}
+	for _, element := range doc.FindElements(declarationPath) {
+		declared[element.SelectAttrValue("id", "")] = true
+	}
+	for _, element := range doc.FindElements("//*[@" + refAttr + "]") {
+		ref := element.SelectAttrValue(refAttr, "")
+		if !declared[ref] {
+			elementId := element.SelectAttrValue("id", "")
+			if elementId == "" && element.Parent() != nil {
+				elementId = element.Parent().SelectAttrValue("id", "")
+			}
+			result = append(result, model.ValidationError{
+				Rule:      rule,
+				ElementId: elementId,
+				Message:   "reference to undeclared '" + ref + "'",
+			})
+		}
+	}
+	return result
+}
+
+var resourceNameRegex = regexp.MustCompile(`^[A-Za-z0-9_\-. ]+\.[A-Za-z0-9]+$`)
+
+// ValidateDeploymentResources checks the additional resources of a deployment
+// names must be unique file names with extension; .bpmn, .dmn and .cmmn content must be xml, .form and .json content must be json
+func ValidateDeploymentResources(deploymentName string, resources []camundamodel.DeploymentResource) (result model.ValidationErrors) {
+	known := map[string]bool{deploymentName + ".bpmn": true, deploymentName + ".svg": true}
+	for _, resource := range resources {
+		if !resourceNameRegex.MatchString(resource.Name) {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleResource, ElementId: resource.Name, Message: "invalid resource name"})
+			continue
+		}
+		if known[resource.Name] {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleResource, ElementId: resource.Name, Message: "duplicate resource name"})
+			continue
+		}
+		known[resource.Name] = true
+		var err error
+		switch path.Ext(resource.Name) {
+		case ".bpmn", ".dmn", ".cmmn":
+			err = xml.Unmarshal([]byte(resource.Content), new(interface{}))
+		case ".form", ".json":
+			err = json.Unmarshal([]byte(resource.Content), new(interface{}))
+		}
+		if err != nil {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleResource, ElementId: resource.Name, Message: err.Error()})
+		}
+	}
+	return result
+}
+
+// ValidateSchedules checks ids, cron expressions and business key templates of the deployment schedules
+func ValidateSchedules(schedules []model.Schedule) (result model.ValidationErrors) {
+	known := map[string]bool{}
+	for _, schedule := range schedules {
+		if schedule.Id == "" {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleSchedule, Message: "missing schedule id"})
+			continue
+		}
+		if known[schedule.Id] {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleSchedule, ElementId: schedule.Id, Message: "duplicate schedule id"})
+			continue
+		}
+		known[schedule.Id] = true
+		_, err := cron.Parse(schedule.Cron)
+		if err != nil {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleSchedule, ElementId: schedule.Id, Message: err.Error()})
+		}
+		_, err = template.New("").Parse(schedule.BusinessKey)
+		if err != nil {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleSchedule, ElementId: schedule.Id, Message: err.Error()})
+		}
+	}
+	return result
+}
+
+func ValidateBusinessKeyPolicy(policy string) (result model.ValidationErrors) {
+	switch policy {
+	case "", model.BusinessKeyPolicyReject, model.BusinessKeyPolicyReplace, model.BusinessKeyPolicyQueue:
+		return nil
+	default:
+		return model.ValidationErrors{{Rule: model.ValidationRulePolicy, Message: fmt.Sprintf("unknown business key policy '%v'", policy)}}
+	}
+}
+
+func ValidateWatchdog(watchdog *model.Watchdog) (result model.ValidationErrors) {
+	if watchdog == nil {
+		return nil
+	}
+	for _, duration := range []string{watchdog.MaxDuration, watchdog.MaxInactivity} {
+		if duration == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(duration); err != nil {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleWatchdog, Message: err.Error()})
+		}
+	}
+	if watchdog.MaxDuration == "" && watchdog.MaxInactivity == "" {
+		result = append(result, model.ValidationError{Rule: model.ValidationRuleWatchdog, Message: "missing max_duration or max_inactivity"})
+	}
+	switch watchdog.Action {
+	case model.WatchdogActionReport, model.WatchdogActionNotify, model.WatchdogActionIncident:
+	default:
+		result = append(result, model.ValidationError{Rule: model.ValidationRuleWatchdog, Message: fmt.Sprintf("unknown watchdog action '%v'", watchdog.Action)})
+	}
+	return result
+}
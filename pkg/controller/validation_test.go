@@ -0,0 +1,101 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/tests/resources"
+)
+
+const validationTestBpmn = `<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="Definitions_1">
+<bpmn:process id="Process_1" isExecutable="true">
+<bpmn:startEvent id="StartEvent_1"><bpmn:messageEventDefinition id="MsgDef_1" messageRef="Message_1"/></bpmn:startEvent>
+<bpmn:serviceTask id="Task_1" camunda:type="external" camunda:topic="pessimistic"/>
+<bpmn:intermediateCatchEvent id="Catch_1"><bpmn:signalEventDefinition id="SigDef_1" signalRef="Signal_1"/></bpmn:intermediateCatchEvent>
+<bpmn:endEvent id="EndEvent_1"/>
+</bpmn:process>
+<bpmn:message id="Message_1" name="msg"/>
+<bpmn:signal id="Signal_1" name="sig"/>
+</bpmn:definitions>`
+
+func TestValidateDeploymentXml(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		errs := ValidateDeploymentXml(validationTestBpmn, []string{"pessimistic"})
+		if len(errs) != 0 {
+			t.Error(errs)
+		}
+	})
+	t.Run("valid resource", func(t *testing.T) {
+		errs := ValidateDeploymentXml(resources.IncidentBpmn, nil)
+		if len(errs) != 0 {
+			t.Error(errs)
+		}
+	})
+	t.Run("empty", testValidationRules("", nil, model.ValidationRuleXml))
+	t.Run("broken xml", testValidationRules("<bpmn:definitions", nil, model.ValidationRuleXml))
+	t.Run("not executable", testValidationRules(strings.Replace(validationTestBpmn, `isExecutable="true"`, `isExecutable="false"`, 1), nil, model.ValidationRuleExecutable))
+	t.Run("duplicate id", testValidationRules(strings.Replace(validationTestBpmn, `id="EndEvent_1"`, `id="Task_1"`, 1), nil, model.ValidationRuleUniqueId))
+	t.Run("unknown topic", testValidationRules(validationTestBpmn, []string{"optimistic"}, model.ValidationRuleTaskTopic))
+	t.Run("undeclared message", testValidationRules(strings.Replace(validationTestBpmn, `<bpmn:message id="Message_1" name="msg"/>`, "", 1), nil, model.ValidationRuleMessageRef))
+	t.Run("undeclared signal", testValidationRules(strings.Replace(validationTestBpmn, `<bpmn:signal id="Signal_1" name="sig"/>`, "", 1), nil, model.ValidationRuleSignalRef))
+}
+
+func testValidationRules(xml string, knownTopics []string, expectedRules ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		errs := ValidateDeploymentXml(xml, knownTopics)
+		if len(errs) != len(expectedRules) {
+			t.Error(errs)
+			return
+		}
+		for i, rule := range expectedRules {
+			if errs[i].Rule != rule {
+				t.Error(i, errs[i], rule)
+			}
+		}
+	}
+}
+
+func TestValidateDeploymentResources(t *testing.T) {
+	valid := []camundamodel.DeploymentResource{
+		{Name: "decision.dmn", Content: `<definitions xmlns="https://www.omg.org/spec/DMN/20191111/MODEL/" id="d"/>`},
+		{Name: "confirm.form", Content: `{"components": []}`},
+	}
+	if errs := ValidateDeploymentResources("test", valid); len(errs) != 0 {
+		t.Error(errs)
+	}
+	invalid := []camundamodel.DeploymentResource{
+		{Name: "../decision.dmn", Content: ""},
+		{Name: "test.bpmn", Content: validationTestBpmn},
+		{Name: "decision.dmn", Content: `<definitions`},
+		{Name: "confirm.form", Content: `{"components": [}`},
+		{Name: "confirm.form", Content: `{}`},
+	}
+	errs := ValidateDeploymentResources("test", invalid)
+	if len(errs) != len(invalid) {
+		t.Error(errs)
+		return
+	}
+	for _, e := range errs {
+		if e.Rule != model.ValidationRuleResource {
+			t.Error(e)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+)
+
+// {"id_":"6b84bb06-750c-11eb-b54c-0242ac110006","proc_def_key_":"ExampleId","proc_def_id_":"ExampleId:1:686e7a53-750c-11eb-b54c-0242ac110006","root_proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","proc_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","execution_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","act_inst_id_":"6b84bb04-750c-11eb-b54c-0242ac110006","case_def_key_":null,"case_def_id_":null,"case_inst_id_":null,"case_execution_id_":null,"task_id_":null,"name_":"energy","var_type_":"double","create_time_":"2021-02-22T12:49:36.890","rev_":0,"bytearray_id_":null,"double_":13.5,"long_":null,"text_":null,"text2_":null,"tenant_id_":"user","state_":"CREATED","removal_time_":null}
+type VariableInstanceInPg struct {
+	Id                    string   `json:"id_"`
+	ProcessDefinitionKey  string   `json:"proc_def_key_"`
+	ProcessDefinitionId   string   `json:"proc_def_id_"`
+	RootProcessInstanceId string   `json:"root_proc_inst_id_"`
+	ProcessInstanceId     string   `json:"proc_inst_id_"`
+	ExecutionId           string   `json:"execution_id_"`
+	ActivityInstanceId    string   `json:"act_inst_id_"`
+	TaskId                string   `json:"task_id_"`
+	Name                  string   `json:"name_"`
+	Type                  string   `json:"var_type_"`
+	CreateTime            string   `json:"create_time_"`
+	ByteArrayId           *string  `json:"bytearray_id_"`
+	Double                *float64 `json:"double_"`
+	Long                  *int64   `json:"long_"`
+	Text                  *string  `json:"text_"`
+	State                 string   `json:"state_"`
+	TenantId              string   `json:"tenant_id_"`
+	Truncated             bool     `json:"truncated_"` //long values are removed from the notification; see pglistener.TruncatedField
+}
+
+const syncedVariablesMaxAge = 30 * time.Second
+
+func (this *Controller) NotifyVariableUpdate(extra string) {
+	element := VariableInstanceInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal variable in NotifyVariableUpdate(): ", err)
+		return
+	}
+	if !this.variableIsSynced(element.ProcessDefinitionKey, element.Name) || !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	variable, err := this.getVariableInstance(element)
+	if err != nil {
+		log.Println("ERROR: unable to get variable value in NotifyVariableUpdate(): ", err)
+		return
+	}
+	variable.Value = redactVariable(this.config.VariableRedactionRules, variable.Name, variable.Value)
+	err = this.backend.SendProcessVariableUpdate(variable)
+	if err != nil {
+		log.Println("ERROR: unable to send variable update in NotifyVariableUpdate(): ", err)
+		return
+	}
+}
+
+func (this *Controller) NotifyVariableDelete(extra string) {
+	element := VariableInstanceInPg{}
+	err := json.Unmarshal([]byte(extra), &element)
+	if err != nil {
+		log.Println("ERROR: unable to unmarshal variable in NotifyVariableDelete(): ", err)
+		return
+	}
+	if !this.variableIsSynced(element.ProcessDefinitionKey, element.Name) || !this.isSyncedDefinition(element.ProcessDefinitionId) {
+		return
+	}
+	err = this.backend.SendProcessVariableDelete(element.Id)
+	if err != nil {
+		log.Println("ERROR: unable to send variable delete in NotifyVariableDelete(): ", err)
+		return
+	}
+}
+
+// getVariableInstance reads the value from the db row
+// values stored as byte array (json, object, bytes, ...) or removed from the notification are loaded from camunda
+func (this *Controller) getVariableInstance(element VariableInstanceInPg) (result camundamodel.HistoricVariableInstance, err error) {
+	if element.ByteArrayId != nil || element.Truncated {
+		result, err = this.camunda.GetHistoricVariableInstance(element.Id, UserId)
+		if err != nil {
+			return result, err
+		}
+		result.ProcessDefinitionKey = element.ProcessDefinitionKey
+		return result, nil
+	}
+	result = camundamodel.HistoricVariableInstance{
+		Id:                    element.Id,
+		Name:                  element.Name,
+		Type:                  element.Type,
+		ProcessDefinitionKey:  element.ProcessDefinitionKey,
+		ProcessDefinitionId:   element.ProcessDefinitionId,
+		ProcessInstanceId:     element.ProcessInstanceId,
+		RootProcessInstanceId: element.RootProcessInstanceId,
+		ExecutionId:           element.ExecutionId,
+		ActivityInstanceId:    element.ActivityInstanceId,
+		TaskId:                element.TaskId,
+		CreateTime:            element.CreateTime,
+		State:                 element.State,
+		TenantId:              element.TenantId,
+	}
+	if result.Type != "" {
+		//camunda rest api names: string -> String
+		result.Type = strings.ToUpper(result.Type[:1]) + result.Type[1:]
+	}
+	switch strings.ToLower(element.Type) {
+	case "long", "integer", "short":
+		if element.Long != nil {
+			result.Value = *element.Long
+		}
+	case "double":
+		if element.Double != nil {
+			result.Value = *element.Double
+		}
+	case "boolean":
+		if element.Long != nil {
+			result.Value = *element.Long == 1
+		}
+	case "date":
+		if element.Long != nil {
+			result.Value = time.UnixMilli(*element.Long).Format(camundaDateFormat)
+		}
+	case "null":
+	default:
+		if element.Text != nil {
+			result.Value = *element.Text
+		}
+	}
+	return result, nil
+}
+
+// variableIsSynced checks the name against the synced_variables of the deployment
+func (this *Controller) variableIsSynced(processDefinitionKey string, name string) bool {
+	for _, pattern := range this.getSyncedVariables(processDefinitionKey) {
+		if match, _ := path.Match(pattern, name); match {
+			return true
+		}
+	}
+	return false
+}
+
+// getSyncedVariables returns the synced_variables of the deployment of the process definition key
+// the synced_variables of all deployments are cached for syncedVariablesMaxAge
+func (this *Controller) getSyncedVariables(processDefinitionKey string) []string {
+	this.syncedVariablesMux.Lock()
+	defer this.syncedVariablesMux.Unlock()
+	if this.syncedVariables == nil || time.Since(this.syncedVariablesUpdate) > syncedVariablesMaxAge {
+		this.syncedVariables = map[string][]string{}
+		this.syncedVariablesUpdate = time.Now()
+		if !this.metadata.IsPlaceholder() {
+			list, err := this.metadata.List()
+			if err != nil {
+				log.Println("ERROR: unable to list deployment metadata for variable sync", err)
+			}
+			for _, md := range list {
+				if len(md.DeploymentModel.SyncedVariables) > 0 {
+					this.syncedVariables[getProcessDefinitionKey(md.DeploymentModel.Id)] = md.DeploymentModel.SyncedVariables
+				}
+			}
+		}
+	}
+	for key, variables := range this.syncedVariables {
+		if isProcessDefinitionKeyOf(processDefinitionKey, key) {
+			return variables
+		}
+	}
+	return nil
+}
+
+func redactVariable(rules []configuration.RedactionRule, name string, value interface{}) interface{} {
+	for _, rule := range rules {
+		if match, _ := path.Match(rule.Variable, name); !match {
+			continue
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = "***"
+		}
+		if rule.Pattern == "" {
+			return replacement
+		}
+		//values the pattern can not be applied to are redacted completely
+		str, ok := value.(string)
+		if !ok {
+			return replacement
+		}
+		exp, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			log.Println("WARNING: invalid variable redaction pattern", rule.Pattern, err)
+			return replacement
+		}
+		value = exp.ReplaceAllString(str, replacement)
+	}
+	return value
+}
+
+func ValidateRedactionRules(rules []configuration.RedactionRule) error {
+	for i, rule := range rules {
+		if _, err := path.Match(rule.Variable, ""); err != nil || rule.Variable == "" {
+			return fmt.Errorf("invalid variable redaction rule %v: invalid variable pattern '%v'", i, rule.Variable)
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("invalid variable redaction rule %v: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func ValidateSyncedVariables(patterns []string) (result model.ValidationErrors) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			result = append(result, model.ValidationError{Rule: model.ValidationRuleVariable, ElementId: pattern, Message: "invalid variable name pattern"})
+		}
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/camunda/shards"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+)
+
+func TestRedactVariable(t *testing.T) {
+	rules := []configuration.RedactionRule{
+		{Variable: "*password*"},
+		{Variable: "note*", Pattern: "[0-9]{4}-[0-9]{4}", Replacement: "<card>"},
+	}
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{name: "db_password", value: "secret", expected: "***"},
+		{name: "note", value: "card 1234-5678 used", expected: "card <card> used"},
+		{name: "energy", value: 13.5, expected: 13.5},
+		{name: "note_count", value: 3.0, expected: "<card>"}, //pattern not applicable to the matched variable
+	}
+	for _, test := range tests {
+		if result := redactVariable(rules, test.name, test.value); result != test.expected {
+			t.Error(test.name, result)
+		}
+	}
+	if err := ValidateRedactionRules(rules); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateRedactionRules([]configuration.RedactionRule{{Variable: "*", Pattern: "("}}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestGetVariableInstance(t *testing.T) {
+	ctrl := &Controller{}
+	long := int64(1)
+	double := 13.5
+	text := "foo"
+	tests := []struct {
+		element      VariableInstanceInPg
+		expected     interface{}
+		expectedType string
+	}{
+		{element: VariableInstanceInPg{Type: "boolean", Long: &long}, expected: true, expectedType: "Boolean"},
+		{element: VariableInstanceInPg{Type: "long", Long: &long}, expected: int64(1), expectedType: "Long"},
+		{element: VariableInstanceInPg{Type: "double", Double: &double}, expected: 13.5, expectedType: "Double"},
+		{element: VariableInstanceInPg{Type: "string", Text: &text}, expected: "foo", expectedType: "String"},
+		{element: VariableInstanceInPg{Type: "null"}, expected: nil, expectedType: "Null"},
+	}
+	for _, test := range tests {
+		result, err := ctrl.getVariableInstance(test.element)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if result.Value != test.expected || result.Type != test.expectedType {
+			t.Errorf("%#v", result)
+		}
+	}
+}
+
+func TestGetTruncatedVariableInstance(t *testing.T) {
+	value := strings.Repeat("ä", 4000)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/engine-rest/history/variable-instance/v1" {
+			http.NotFound(writer, request)
+			return
+		}
+		json.NewEncoder(writer).Encode(camundamodel.HistoricVariableInstance{Id: "v1", Name: "note", Type: "String", Value: value})
+	}))
+	defer server.Close()
+	config := configuration.Config{}
+	ctrl := &Controller{config: config, camunda: camunda.New(config, shards.Shards(server.URL))}
+
+	element := VariableInstanceInPg{}
+	err := json.Unmarshal([]byte(`{"id_":"v1","proc_def_key_":"deplid_d1","name_":"note","var_type_":"string","text_":null,"truncated_":true}`), &element)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := ctrl.getVariableInstance(element)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.Value != value || result.ProcessDefinitionKey != "deplid_d1" {
+		t.Errorf("%#v", result.ProcessDefinitionKey)
+	}
+}
+
+func TestVariableIsSynced(t *testing.T) {
+	ctrl := newTestController(t, configuration.Config{}, nil, metadata.Metadata{
+		CamundaDeploymentId: "c1",
+		DeploymentModel: model.FogDeploymentMessage{
+			Deployment:      deploymentmodel.Deployment{Id: "d1"},
+			SyncedVariables: []string{"energy", "result_*"},
+		},
+	})
+	key := getProcessDefinitionKey("d1")
+	for name, expected := range map[string]bool{"energy": true, "result_a": true, "password": false} {
+		if result := ctrl.variableIsSynced(key, name); result != expected {
+			t.Error(name, result)
+		}
+	}
+	if ctrl.variableIsSynced(getProcessDefinitionKey("d2"), "energy") {
+		t.Error("unexpected sync of other deployment")
+	}
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/controller/notification"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/google/uuid"
+)
+
+type watchedInstance struct {
+	DefinitionId string
+	BusinessKey  string
+	Start        time.Time
+	LastActivity time.Time
+	Flagged      map[string]bool //by reason; every reason is reported once
+}
+
+func (this *Controller) watchdogEnabled() bool {
+	return this.config.WatchdogCheckInterval != "" && this.config.WatchdogCheckInterval != "-"
+}
+
+// startWatchdog periodically checks the running instances against the watchdog of their deployment
+// running instances are tracked by the instance and history notifications
+func (this *Controller) startWatchdog(ctx context.Context) {
+	if !this.watchdogEnabled() || this.metadata.IsPlaceholder() {
+		return
+	}
+	interval, err := time.ParseDuration(this.config.WatchdogCheckInterval)
+	if err != nil {
+		log.Println("WARNING: unable to parse watchdog check interval", this.config.WatchdogCheckInterval, err)
+		return
+	}
+	this.initWatchedInstances()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case t := <-ticker.C:
+				for _, stuck := range this.checkWatchdog(t) {
+					this.handleStuckInstance(stuck)
+				}
+			}
+		}
+	}()
+}
+
+// initWatchedInstances tracks instances started before the client; the last activity is unknown and set to now
+func (this *Controller) initWatchedInstances() {
+	instances, err := this.camunda.GetProcessInstanceHistoryListUnfinished(UserId)
+	if err != nil {
+		log.Println("WARNING: unable to load running instances for watchdog", err)
+		return
+	}
+	now := time.Now()
+	for _, instance := range instances {
+		if instance.SuperProcessInstanceId != "" {
+			continue
+		}
+		start, err := time.Parse(camundaDateFormat, instance.StartTime)
+		if err != nil {
+			start = now
+		}
+		this.watchInstance(instance.Id, instance.ProcessDefinitionId, instance.BusinessKey, start, now)
+	}
+}
+
+func (this *Controller) watchInstance(instanceId string, definitionId string, businessKey string, start time.Time, lastActivity time.Time) {
+	this.watchdogMux.Lock()
+	defer this.watchdogMux.Unlock()
+	if this.watchedInstances == nil {
+		this.watchedInstances = map[string]*watchedInstance{}
+	}
+	if _, ok := this.watchedInstances[instanceId]; ok {
+		return
+	}
+	this.watchedInstances[instanceId] = &watchedInstance{
+		DefinitionId: definitionId,
+		BusinessKey:  businessKey,
+		Start:        start,
+		LastActivity: lastActivity,
+		Flagged:      map[string]bool{},
+	}
+}
+
+// onInstanceActivity is called for every execution change of the instance
+func (this *Controller) onInstanceActivity(instanceId string, definitionId string, businessKey string, root bool, now time.Time) {
+	if !this.watchdogEnabled() || this.metadata.IsPlaceholder() {
+		return
+	}
+	if root {
+		this.watchInstance(instanceId, definitionId, businessKey, now, now)
+	}
+	this.watchdogMux.Lock()
+	defer this.watchdogMux.Unlock()
+	if instance, ok := this.watchedInstances[instanceId]; ok {
+		instance.LastActivity = now
+		delete(instance.Flagged, model.StuckReasonMaxInactivity)
+	}
+}
+
+func (this *Controller) unwatchInstance(instanceId string) {
+	this.watchdogMux.Lock()
+	defer this.watchdogMux.Unlock()
+	delete(this.watchedInstances, instanceId)
+}
+
+// checkWatchdog returns newly flagged instances
+func (this *Controller) checkWatchdog(now time.Time) (result []model.StuckProcessInstance) {
+	watchdogs := map[string]metadata.Metadata{}
+	list, err := this.metadata.List()
+	if err != nil {
+		log.Println("ERROR: unable to list deployment metadata for watchdog", err)
+		return nil
+	}
+	for _, md := range list {
+		if md.DeploymentModel.Watchdog != nil {
+			watchdogs[md.CamundaDeploymentId] = md
+		}
+	}
+	if len(watchdogs) == 0 {
+		return nil
+	}
+
+	//resolving deployments may need camunda requests and is done without holding watchdogMux
+	deploymentIds := map[string]string{} //by definition id
+	this.watchdogMux.Lock()
+	for _, instance := range this.watchedInstances {
+		deploymentIds[instance.DefinitionId] = ""
+	}
+	this.watchdogMux.Unlock()
+	for definitionId := range deploymentIds {
+		deploymentIds[definitionId] = this.getDeploymentIdOfDefinition(definitionId)
+	}
+
+	this.watchdogMux.Lock()
+	defer this.watchdogMux.Unlock()
+	for instanceId, instance := range this.watchedInstances {
+		deploymentId, ok := deploymentIds[instance.DefinitionId]
+		if !ok {
+			//watched after the deployments were resolved; checked on the next run
+			continue
+		}
+		md, ok := watchdogs[deploymentId]
+		if !ok {
+			continue
+		}
+		watchdog := md.DeploymentModel.Watchdog
+		for _, check := range []struct {
+			reason string
+			limit  string
+			since  time.Time
+		}{
+			{reason: model.StuckReasonMaxDuration, limit: watchdog.MaxDuration, since: instance.Start},
+			{reason: model.StuckReasonMaxInactivity, limit: watchdog.MaxInactivity, since: instance.LastActivity},
+		} {
+			if check.limit == "" || instance.Flagged[check.reason] {
+				continue
+			}
+			limit, err := time.ParseDuration(check.limit)
+			if err != nil {
+				log.Println("WARNING: invalid watchdog", md.CamundaDeploymentId, err)
+				continue
+			}
+			if now.Sub(check.since) <= limit {
+				continue
+			}
+			instance.Flagged[check.reason] = true
+			result = append(result, model.StuckProcessInstance{
+				ProcessInstanceId:   instanceId,
+				ProcessDefinitionId: instance.DefinitionId,
+				CamundaDeploymentId: md.CamundaDeploymentId,
+				DeploymentId:        md.DeploymentModel.Id,
+				DeploymentName:      md.DeploymentModel.Name,
+				BusinessKey:         instance.BusinessKey,
+				Reason:              check.reason,
+				StartTime:           instance.Start,
+				LastActivity:        instance.LastActivity,
+				Action:              watchdog.Action,
+			})
+		}
+	}
+	return result
+}
+
+func (this *Controller) handleStuckInstance(stuck model.StuckProcessInstance) {
+	log.Printf("WARNING: stuck process instance %v of %v (%v)", stuck.ProcessInstanceId, stuck.DeploymentName, stuck.Reason)
+	err := this.backend.SendStuckProcessInstance(stuck)
+	if err != nil {
+		log.Println("ERROR: unable to send stuck process instance", err)
+	}
+	message := fmt.Sprintf("process instance %v (business key '%v') exceeded %v; started at %v, last activity at %v", stuck.ProcessInstanceId, stuck.BusinessKey, stuck.Reason, stuck.StartTime.Format(time.RFC3339), stuck.LastActivity.Format(time.RFC3339))
+	switch stuck.Action {
+	case model.WatchdogActionNotify:
+		err = notification.Send(this.config.NotificationUrl, notification.Message{
+			Title:   "Fog Process-Watchdog in " + stuck.DeploymentName,
+			Message: message,
+			Topic:   notification.Topic,
+		})
+		if err != nil {
+			log.Println("ERROR: unable to send watchdog notification", err)
+		}
+	case model.WatchdogActionIncident:
+		incident := camundamodel.Incident{
+			Id:                  uuid.NewString(),
+			ProcessInstanceId:   stuck.ProcessInstanceId,
+			ProcessDefinitionId: stuck.ProcessDefinitionId,
+			WorkerId:            "mgw-process-sync-client",
+			ErrorMessage:        "watchdog: " + message,
+			Time:                time.Now(),
+			TenantId:            UserId,
+			DeploymentName:      stuck.DeploymentName,
+			BusinessKey:         stuck.BusinessKey,
+		}
+		err = this.backend.SendIncident(incident)
+		if err != nil {
+			log.Println("WARNING: unable to send incident:", err)
+		}
+		err = this.HandleIncident(incident)
+		if err != nil {
+			log.Println("ERROR: unable to handle watchdog incident", err)
+		}
+	}
+}
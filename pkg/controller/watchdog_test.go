@@ -0,0 +1,103 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/metadata"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+)
+
+func TestCheckWatchdog(t *testing.T) {
+	ctrl := newTestController(t, configuration.Config{WatchdogCheckInterval: "1m"}, nil, metadata.Metadata{
+		CamundaDeploymentId: "c1",
+		DeploymentModel: model.FogDeploymentMessage{
+			Deployment: deploymentmodel.Deployment{Id: "d1", Name: "monitor"},
+			Watchdog:   &model.Watchdog{MaxDuration: "1h", MaxInactivity: "10m"},
+		},
+	})
+	ctrl.definitions = map[string]camundamodel.ProcessDefinition{"def1": {Id: "def1", DeploymentId: "c1"}, "def2": {Id: "def2", DeploymentId: "c2"}}
+
+	start := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+	ctrl.onInstanceActivity("i1", "def1", "room1", true, start)
+	ctrl.onInstanceActivity("i2", "def2", "room2", true, start) //deployment without watchdog
+
+	if stuck := ctrl.checkWatchdog(start.Add(5 * time.Minute)); len(stuck) != 0 {
+		t.Error(stuck)
+	}
+
+	ctrl.onInstanceActivity("i1", "def1", "", false, start.Add(5*time.Minute))
+	stuck := ctrl.checkWatchdog(start.Add(16 * time.Minute))
+	if len(stuck) != 1 || stuck[0].Reason != model.StuckReasonMaxInactivity || stuck[0].ProcessInstanceId != "i1" || stuck[0].BusinessKey != "room1" || stuck[0].DeploymentId != "d1" {
+		t.Errorf("%#v", stuck)
+	}
+
+	//reported once
+	if stuck := ctrl.checkWatchdog(start.Add(17 * time.Minute)); len(stuck) != 0 {
+		t.Error(stuck)
+	}
+
+	ctrl.onInstanceActivity("i1", "def1", "", false, start.Add(55*time.Minute))
+	stuck = ctrl.checkWatchdog(start.Add(61 * time.Minute))
+	if len(stuck) != 1 || stuck[0].Reason != model.StuckReasonMaxDuration {
+		t.Errorf("%#v", stuck)
+	}
+
+	ctrl.unwatchInstance("i1")
+	if stuck := ctrl.checkWatchdog(start.Add(5 * time.Hour)); len(stuck) != 0 {
+		t.Error(stuck)
+	}
+}
+
+func TestInitWatchedInstances(t *testing.T) {
+	start := time.Now().Add(-2 * time.Hour)
+	fake := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		json.NewEncoder(writer).Encode(camundamodel.HistoricProcessInstances{
+			{Id: "i1", ProcessDefinitionId: "def1", StartTime: start.Format(camundaDateFormat)},
+			{Id: "sub", ProcessDefinitionId: "def1", StartTime: start.Format(camundaDateFormat), SuperProcessInstanceId: "i1"},
+		})
+	})
+	ctrl := newTestController(t, configuration.Config{WatchdogCheckInterval: "1m"}, fake, metadata.Metadata{
+		CamundaDeploymentId: "c1",
+		DeploymentModel: model.FogDeploymentMessage{
+			Watchdog: &model.Watchdog{MaxDuration: "1h", MaxInactivity: "10m"},
+		},
+	})
+	ctrl.definitions = map[string]camundamodel.ProcessDefinition{"def1": {Id: "def1", DeploymentId: "c1"}}
+
+	ctrl.initWatchedInstances()
+	if len(ctrl.watchedInstances) != 1 {
+		t.Error(ctrl.watchedInstances)
+	}
+
+	//the last activity of instances started before the client is unknown and must not be flagged as inactive
+	stuck := ctrl.checkWatchdog(time.Now().Add(time.Minute))
+	if len(stuck) != 1 || stuck[0].Reason != model.StuckReasonMaxDuration || !stuck[0].StartTime.Equal(start.Truncate(time.Millisecond)) {
+		t.Errorf("%#v", stuck)
+	}
+	stuck = ctrl.checkWatchdog(time.Now().Add(11 * time.Minute))
+	if len(stuck) != 1 || stuck[0].Reason != model.StuckReasonMaxInactivity {
+		t.Errorf("%#v", stuck)
+	}
+}
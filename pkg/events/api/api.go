@@ -23,6 +23,7 @@ import (
 	"github.com/SENERGY-Platform/event-worker/pkg/model"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/events/api/util"
+	processmodel "github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
 	"github.com/julienschmidt/httprouter"
 	"log"
 	"net/http"
@@ -34,17 +35,21 @@ type Repo interface {
 	Find(localDeviceId string, localServiceId string) ([]model.EventDesc, error)
 }
 
-type EndpointMethod = func(config configuration.Config, router *httprouter.Router, repo Repo)
+type Deployments interface {
+	DryRunDeployment(deployment processmodel.FogDeploymentMessage) processmodel.DeploymentDryRunResult
+}
+
+type EndpointMethod = func(config configuration.Config, router *httprouter.Router, repo Repo, deployments Deployments)
 
 var endpoints = []interface{}{}
 
-func Start(ctx context.Context, config configuration.Config, repo Repo) (err error) {
+func Start(ctx context.Context, config configuration.Config, repo Repo, deployments Deployments) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = errors.New(fmt.Sprint(r))
 		}
 	}()
-	router := GetRouter(config, repo)
+	router := GetRouter(config, repo, deployments)
 
 	server := &http.Server{Addr: ":" + config.EventApiPort, Handler: router}
 	go func() {
@@ -61,12 +66,12 @@ func Start(ctx context.Context, config configuration.Config, repo Repo) (err err
 	return
 }
 
-func GetRouter(config configuration.Config, repo Repo) http.Handler {
+func GetRouter(config configuration.Config, repo Repo, deployments Deployments) http.Handler {
 	router := httprouter.New()
 	for _, e := range endpoints {
 		for name, call := range getEndpointMethods(e) {
 			log.Println("add endpoint " + name)
-			call(config, router, repo)
+			call(config, router, repo, deployments)
 		}
 	}
 
@@ -80,7 +85,7 @@ func GetRouter(config configuration.Config, repo Repo) http.Handler {
 	return handler
 }
 
-func getEndpointMethods(e interface{}) map[string]func(config configuration.Config, router *httprouter.Router, repo Repo) {
+func getEndpointMethods(e interface{}) map[string]func(config configuration.Config, router *httprouter.Router, repo Repo, deployments Deployments) {
 	result := map[string]EndpointMethod{}
 	objRef := reflect.ValueOf(e)
 	methodCount := objRef.NumMethod()
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/configuration"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+func init() {
+	endpoints = append(endpoints, &DeploymentEndpoints{})
+}
+
+type DeploymentEndpoints struct{}
+
+// DryRun godoc
+// @Summary      deployment dry-run
+// @Description  runs the transformation and validation of a deployment without deploying it; returns the resulting xml, the applied rewrites and warnings
+// @Tags         deployment
+// @Accept       json
+// @Produce      json
+// @Param        message body object true "deployment; same format as the payload of the cmd/deployment mqtt topic"
+// @Success      200 {object} model.DeploymentDryRunResult
+// @Failure      400
+// @Router       /deployments/dry-run [post]
+func (this *DeploymentEndpoints) DryRun(config configuration.Config, router *httprouter.Router, repo Repo, deployments Deployments) {
+	router.POST("/deployments/dry-run", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		deployment := model.FogDeploymentMessage{}
+		err := json.NewDecoder(request.Body).Decode(&deployment)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(deployments.DryRunDeployment(deployment))
+	})
+}
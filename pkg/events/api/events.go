@@ -39,7 +39,7 @@ type Events struct{}
 // @Success      200 {array} []model.EventDesc
 // @Failure      500
 // @Router       /event-descriptions [get]
-func (this *Events) Find(config configuration.Config, router *httprouter.Router, repo Repo) {
+func (this *Events) Find(config configuration.Config, router *httprouter.Router, repo Repo, deployments Deployments) {
 	router.GET("/event-descriptions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 		localDeviceId := request.URL.Query().Get("local_device_id")
 		localServiceId := request.URL.Query().Get("local_service_id")
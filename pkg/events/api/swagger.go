@@ -32,7 +32,7 @@ func init() {
 
 type SwaggerEndpoints struct{}
 
-func (this *SwaggerEndpoints) Swagger(config configuration.Config, router *httprouter.Router, repo Repo) {
+func (this *SwaggerEndpoints) Swagger(config configuration.Config, router *httprouter.Router, repo Repo, deployments Deployments) {
 	if config.EnableSwaggerUi {
 		router.GET("/swagger/:any", func(res http.ResponseWriter, req *http.Request, p httprouter.Params) {
 			httpSwagger.WrapHandler(res, req)
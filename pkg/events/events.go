@@ -23,11 +23,11 @@ import (
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/events/repo"
 )
 
-func StartApi(ctx context.Context, config configuration.Config) (r *repo.EventRepo, err error) {
+func StartApi(ctx context.Context, config configuration.Config, deployments api.Deployments) (r *repo.EventRepo, err error) {
 	r, err = repo.New(ctx, config)
 	if err != nil {
 		return r, err
 	}
-	err = api.Start(ctx, config, r)
+	err = api.Start(ctx, config, r, deployments)
 	return r, err
 }
@@ -17,6 +17,8 @@
 package metadata
 
 import (
+	"time"
+
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model"
 	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
 )
@@ -24,7 +26,13 @@ import (
 type Metadata struct {
 	CamundaDeploymentId string                           `json:"camunda_deployment_id"`
 	ProcessParameter    map[string]camundamodel.Variable `json:"process_parameter"`
+	RequiredParameter   []string                         `json:"required_parameter,omitempty"` //start form fields with a camunda:validation "required" constraint
 	DeploymentModel     model.FogDeploymentMessage       `json:"deployment_model"`
+	Resources           []string                         `json:"resources"`          //names of the additional resources deployed to camunda
+	ContentHash         string                           `json:"content_hash"`       //hash of DeploymentModel to detect redelivered deployment commands
+	XmlHash             string                           `json:"xml_hash,omitempty"` //hash of the xml deployed to camunda to detect drift
+	ScheduleLastRuns    map[string]time.Time             `json:"schedule_last_runs,omitempty"`
+	Kpi                 *model.KpiWindow                 `json:"kpi,omitempty"` //current kpi window
 }
 
 type Storage interface {
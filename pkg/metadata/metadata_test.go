@@ -47,9 +47,14 @@ func MetadataTest(storage Storage) func(t *testing.T) {
 		}
 
 		md3 := Metadata{
-			DeploymentModel: model.FogDeploymentMessage{Deployment: deploymentmodel.Deployment{
-				Name: "dpl3",
-			}},
+			DeploymentModel: model.FogDeploymentMessage{
+				Deployment: deploymentmodel.Deployment{
+					Name: "dpl3",
+				},
+				//ensures the additional fields survive the json round trip next to the embedded deployment
+				Resources: []camundamodel.DeploymentResource{{Name: "decision.dmn", Content: "<definitions/>"}},
+			},
+			Resources: []string{"decision.dmn"},
 			ProcessParameter: map[string]camundamodel.Variable{
 				"var_1": {Type: "string"},
 			},
@@ -33,6 +33,12 @@ type ProcessInstance struct {
 	Ended          bool   `json:"ended,omitempty"`
 	Suspended      bool   `json:"suspended,omitempty"`
 	TenantId       string `json:"tenantId,omitempty"`
+
+	//call hierarchy; not part of the camunda rest api
+	SuperProcessInstanceId string `json:"superProcessInstanceId,omitempty"`
+	RootProcessInstanceId  string `json:"rootProcessInstanceId,omitempty"`
+	DeploymentId           string `json:"deploymentId,omitempty"`
+	RootDeploymentId       string `json:"rootDeploymentId,omitempty"`
 }
 
 // /engine-rest/process-instance?tenantIdIn="+url.QueryEscape(userId)
@@ -66,6 +72,12 @@ func (this *ProcessDefinition) GetDeploymentId() (id string) {
 // /engine-rest/process-definition?deploymentId="+url.QueryEscape(id)
 type ProcessDefinitions = []ProcessDefinition
 
+// /engine-rest/process-definition/" + processDefinitionId + "/xml"
+type ProcessDefinitionXml struct {
+	Id        string `json:"id"`
+	Bpmn20Xml string `json:"bpmn20Xml"`
+}
+
 // /engine-rest/deployment/"+url.QueryEscape(id)
 // /engine-rest/deployment/"+url.QueryEscape(deploymentId)
 // /engine-rest/deployment/" + id + "?cascade=true
@@ -88,6 +100,12 @@ func (this *Deployment) GetDeploymentId() (id string) {
 // /engine-rest/deployment?tenantIdIn="+url.QueryEscape(userId)+"&"+params.Encode()
 type Deployments = []Deployment
 
+// additional file of a deployment (e.g. .dmn or .form), uploaded with /engine-rest/deployment/create
+type DeploymentResource struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
 // /engine-rest/process-instance/count?tenantIdIn="+url.QueryEscape(userId)
 // /engine-rest/incident/count?processDefinitionId="+url.QueryEscape(definitionId)
 type Count struct {
@@ -98,6 +116,7 @@ type Count struct {
 type HistoricProcessInstance struct {
 	Id                       string  `json:"id"`
 	SuperProcessInstanceId   string  `json:"superProcessInstanceId"`
+	RootProcessInstanceId    string  `json:"rootProcessInstanceId"`
 	SuperCaseInstanceId      string  `json:"superCaseInstanceId"`
 	CaseInstanceId           string  `json:"caseInstanceId"`
 	ProcessDefinitionName    string  `json:"processDefinitionName"`
@@ -113,6 +132,117 @@ type HistoricProcessInstance struct {
 	DeleteReason             string  `json:"deleteReason"`
 	TenantId                 string  `json:"tenantId"`
 	State                    string  `json:"state"`
+	DeploymentId             string  `json:"deploymentId,omitempty"`     //not part of the camunda rest api
+	RootDeploymentId         string  `json:"rootDeploymentId,omitempty"` //deployment of the root process instance; not part of the camunda rest api
+}
+
+// /engine-rest/history/activity-instance?tenantIdIn="+url.QueryEscape(userId)
+type HistoricActivityInstance struct {
+	Id                       string  `json:"id"`
+	ParentActivityInstanceId string  `json:"parentActivityInstanceId"`
+	ActivityId               string  `json:"activityId"`
+	ActivityName             string  `json:"activityName"`
+	ActivityType             string  `json:"activityType"`
+	ProcessDefinitionKey     string  `json:"processDefinitionKey"`
+	ProcessDefinitionId      string  `json:"processDefinitionId"`
+	ProcessInstanceId        string  `json:"processInstanceId"`
+	ExecutionId              string  `json:"executionId"`
+	TaskId                   string  `json:"taskId"`
+	CalledProcessInstanceId  string  `json:"calledProcessInstanceId"`
+	Assignee                 string  `json:"assignee"`
+	StartTime                string  `json:"startTime"`
+	EndTime                  string  `json:"endTime"`
+	DurationInMillis         float64 `json:"durationInMillis"`
+	Canceled                 bool    `json:"canceled"`
+	CompleteScope            bool    `json:"completeScope"`
+	TenantId                 string  `json:"tenantId"`
+}
+
+type HistoricActivityInstances = []HistoricActivityInstance
+
+// /engine-rest/external-task?tenantIdIn="+url.QueryEscape(userId)
+type ExternalTask struct {
+	Id                   string  `json:"id"`
+	TopicName            string  `json:"topicName"`
+	WorkerId             string  `json:"workerId"`
+	LockExpirationTime   string  `json:"lockExpirationTime"`
+	ActivityId           string  `json:"activityId"`
+	ActivityInstanceId   string  `json:"activityInstanceId"`
+	ExecutionId          string  `json:"executionId"`
+	ProcessInstanceId    string  `json:"processInstanceId"`
+	ProcessDefinitionId  string  `json:"processDefinitionId"`
+	ProcessDefinitionKey string  `json:"processDefinitionKey"`
+	BusinessKey          string  `json:"businessKey"`
+	Retries              *int64  `json:"retries"` //nil if never failed
+	ErrorMessage         string  `json:"errorMessage"`
+	ErrorDetails         string  `json:"errorDetails,omitempty"` //from /engine-rest/external-task/{id}/errorDetails
+	Suspended            bool    `json:"suspended"`
+	Priority             float64 `json:"priority"`
+	TenantId             string  `json:"tenantId"`
+}
+
+type ExternalTasks = []ExternalTask
+
+// /engine-rest/job?tenantIdIn="+url.QueryEscape(userId)+"&timers=true"
+type Job struct {
+	Id                   string  `json:"id"`
+	Type                 string  `json:"type"` //timer or message (async continuation); not part of the camunda rest api
+	JobDefinitionId      string  `json:"jobDefinitionId"`
+	ProcessInstanceId    string  `json:"processInstanceId"`
+	ProcessDefinitionId  string  `json:"processDefinitionId"`
+	ProcessDefinitionKey string  `json:"processDefinitionKey"`
+	ExecutionId          string  `json:"executionId"`
+	ExceptionMessage     string  `json:"exceptionMessage"`
+	FailedActivityId     string  `json:"failedActivityId"`
+	Retries              int64   `json:"retries"`
+	DueDate              string  `json:"dueDate"`
+	Suspended            bool    `json:"suspended"`
+	Priority             float64 `json:"priority"`
+	TenantId             string  `json:"tenantId"`
+	CreateTime           string  `json:"createTime"`
+}
+
+type Jobs = []Job
+
+// /engine-rest/task?tenantIdIn="+url.QueryEscape(userId)
+type Task struct {
+	Id                  string              `json:"id"`
+	Name                string              `json:"name"`
+	Assignee            string              `json:"assignee"`
+	Owner               string              `json:"owner"`
+	Created             string              `json:"created"`
+	Due                 string              `json:"due"`
+	FollowUp            string              `json:"followUp"`
+	Description         string              `json:"description"`
+	ExecutionId         string              `json:"executionId"`
+	ProcessInstanceId   string              `json:"processInstanceId"`
+	ProcessDefinitionId string              `json:"processDefinitionId"`
+	TaskDefinitionKey   string              `json:"taskDefinitionKey"`
+	Priority            float64             `json:"priority"`
+	Suspended           bool                `json:"suspended"`
+	TenantId            string              `json:"tenantId"`
+	FormVariables       map[string]Variable `json:"formVariables,omitempty"` //from /engine-rest/task/{id}/form-variables
+}
+
+type Tasks = []Task
+
+// /engine-rest/history/variable-instance/"+url.QueryEscape(id)
+type HistoricVariableInstance struct {
+	Id                    string      `json:"id"`
+	Name                  string      `json:"name"`
+	Type                  string      `json:"type"`
+	Value                 interface{} `json:"value"`
+	ValueInfo             interface{} `json:"valueInfo"`
+	ProcessDefinitionKey  string      `json:"processDefinitionKey"`
+	ProcessDefinitionId   string      `json:"processDefinitionId"`
+	ProcessInstanceId     string      `json:"processInstanceId"`
+	RootProcessInstanceId string      `json:"rootProcessInstanceId"`
+	ExecutionId           string      `json:"executionId"`
+	ActivityInstanceId    string      `json:"activityInstanceId"`
+	TaskId                string      `json:"taskId"`
+	CreateTime            string      `json:"createTime"`
+	State                 string      `json:"state"`
+	TenantId              string      `json:"tenantId"`
 }
 
 // /engine-rest/history/process-instance?processDefinitionId="+url.QueryEscape(id)
@@ -17,16 +17,175 @@
 package model
 
 import (
-	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"time"
+
+	eventmodel "github.com/SENERGY-Platform/event-worker/pkg/model"
+	"github.com/SENERGY-Platform/mgw-process-sync-client/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
 )
 
 type StartMessage struct {
-	DeploymentId string                 `json:"deployment_id"`
-	Parameter    map[string]interface{} `json:"parameter"`
-	BusinessKey  string                 `json:"business_key"`
+	DeploymentId   string                           `json:"deployment_id"`
+	Parameter      map[string]interface{}           `json:"parameter"`
+	TypedParameter map[string]camundamodel.Variable `json:"typed_parameter,omitempty"` //overwrites entries of Parameter with the same name
+	BusinessKey    string                           `json:"business_key"`
+}
+
+type DecisionEvaluateMessage struct {
+	RequestId      string                           `json:"request_id"`
+	DecisionKey    string                           `json:"decision_key"`
+	Variables      map[string]interface{}           `json:"variables"`
+	TypedVariables map[string]camundamodel.Variable `json:"typed_variables,omitempty"` //overwrites entries of Variables with the same name
+}
+
+type UserTaskCompleteMessage struct {
+	TaskId         string                           `json:"task_id"`
+	Variables      map[string]interface{}           `json:"variables"`
+	TypedVariables map[string]camundamodel.Variable `json:"typed_variables,omitempty"` //overwrites entries of Variables with the same name
+}
+
+// MergeTypedVariables returns the plain values combined with the typed variables
+// typed variables are stored as camundamodel.Variable and overwrite plain values with the same name
+func MergeTypedVariables(plain map[string]interface{}, typed map[string]camundamodel.Variable) map[string]interface{} {
+	if len(typed) == 0 {
+		return plain
+	}
+	result := map[string]interface{}{}
+	for key, value := range plain {
+		result[key] = value
+	}
+	for key, value := range typed {
+		result[key] = value
+	}
+	return result
+}
+
+type DecisionEvaluateResult struct {
+	RequestId   string                             `json:"request_id"`
+	DecisionKey string                             `json:"decision_key"`
+	Result      []map[string]camundamodel.Variable `json:"result"` //one entry per matched rule
+	Error       string                             `json:"error,omitempty"`
+}
+
+// FogDeploymentMessage extends the process-sync model.DeploymentWithEventDesc
+// its fields are repeated instead of embedded to keep the json shape and the composite literals of the original type
+type FogDeploymentMessage struct {
+	deploymentmodel.Deployment
+	EventDescriptions  []eventmodel.EventDesc            `json:"event_descriptions"`
+	DeviceIdToLocalId  map[string]string                 `json:"device_id_to_local_id"`
+	ServiceIdToLocalId map[string]string                 `json:"service_id_to_local_id"`
+	Resources          []camundamodel.DeploymentResource `json:"resources,omitempty"`           //additional deployment resources like .dmn or .form files
+	Schedules          []Schedule                        `json:"schedules,omitempty"`           //process starts executed locally by the client
+	BusinessKeyPolicy  string                            `json:"business_key_policy,omitempty"` //handling of starts with the business key of a running instance; one of BusinessKeyPolicy*, empty to always start
+	KeepAlive          *KeepAlive                        `json:"keep_alive,omitempty"`          //keeps exactly one instance of the process running
+	Watchdog           *Watchdog                         `json:"watchdog,omitempty"`
+	SyncedVariables    []string                          `json:"synced_variables,omitempty"` //names (or path.Match patterns) of process variables sent to the cloud
+	Labels             map[string]string                 `json:"labels,omitempty"`           //usable in sync_include and sync_exclude filters
+}
+
+// Watchdog flags running instances which exceed the max duration or are inactive longer than max inactivity
+type Watchdog struct {
+	MaxDuration   string `json:"max_duration,omitempty"`   //duration like "2h"; empty to disable
+	MaxInactivity string `json:"max_inactivity,omitempty"` //time without execution changes; empty to disable
+	Action        string `json:"action,omitempty"`         //one of WatchdogAction*
+}
+
+const (
+	WatchdogActionReport   = ""         //only publish the stuck instance
+	WatchdogActionNotify   = "notify"   //additionally send a notification
+	WatchdogActionIncident = "incident" //additionally report and handle an incident with the incident handling of the deployment
+)
+
+const (
+	StuckReasonMaxDuration   = "max_duration"
+	StuckReasonMaxInactivity = "max_inactivity"
+)
+
+type StuckProcessInstance struct {
+	ProcessInstanceId   string    `json:"process_instance_id"`
+	ProcessDefinitionId string    `json:"process_definition_id"`
+	CamundaDeploymentId string    `json:"camunda_deployment_id"`
+	DeploymentId        string    `json:"deployment_id"`
+	DeploymentName      string    `json:"deployment_name"`
+	BusinessKey         string    `json:"business_key"`
+	Reason              string    `json:"reason"`
+	StartTime           time.Time `json:"start_time"`
+	LastActivity        time.Time `json:"last_activity"`
+	Action              string    `json:"action"`
+}
+
+// KeepAlive describes the start of a singleton instance which is restarted by the client if it ends
+type KeepAlive struct {
+	BusinessKey    string                           `json:"business_key"`
+	Parameter      map[string]interface{}           `json:"parameter"`
+	TypedParameter map[string]camundamodel.Variable `json:"typed_parameter,omitempty"` //overwrites entries of Parameter with the same name
+}
+
+const (
+	BusinessKeyPolicyReject  = "reject"  //do not start if an instance with the same business key is running
+	BusinessKeyPolicyReplace = "replace" //stop running instances with the same business key before the start
+	BusinessKeyPolicyQueue   = "queue"   //start after the running instance with the same business key has ended
+)
+
+const (
+	BusinessKeyDecisionStarted  = "started"
+	BusinessKeyDecisionRejected = "rejected"
+	BusinessKeyDecisionReplaced = "replaced"
+	BusinessKeyDecisionQueued   = "queued"
+)
+
+// BusinessKeyDecision reports how a start command was handled by the business key policy of the deployment
+type BusinessKeyDecision struct {
+	CamundaDeploymentId string   `json:"camunda_deployment_id"`
+	BusinessKey         string   `json:"business_key"`
+	Policy              string   `json:"policy"`
+	Decision            string   `json:"decision"`
+	RunningInstanceIds  []string `json:"running_instance_ids,omitempty"`
+	Error               string   `json:"error,omitempty"`
+}
+
+type Schedule struct {
+	Id             string                           `json:"id"`
+	Cron           string                           `json:"cron"`         //minute hour day-of-month month day-of-week; e.g. "*/15 * * * *" or "@daily"
+	BusinessKey    string                           `json:"business_key"` //text/template with .DeploymentId, .ScheduleId and .Time; e.g. "room1-{{.Time.Format \"2006-01-02T15:04\"}}"
+	Parameter      map[string]interface{}           `json:"parameter"`
+	TypedParameter map[string]camundamodel.Variable `json:"typed_parameter,omitempty"` //overwrites entries of Parameter with the same name
 }
 
-type FogDeploymentMessage = model.DeploymentWithEventDesc
+type ScheduleExecution struct {
+	DeploymentId        string    `json:"deployment_id"`
+	CamundaDeploymentId string    `json:"camunda_deployment_id"`
+	ScheduleId          string    `json:"schedule_id"`
+	BusinessKey         string    `json:"business_key"`
+	Time                time.Time `json:"time"`
+	Error               string    `json:"error,omitempty"`
+}
+
+type DeploymentDryRunResult struct {
+	DeploymentId     string           `json:"deployment_id"`
+	Xml              string           `json:"xml"`
+	Rewrites         []string         `json:"rewrites"`
+	Warnings         []string         `json:"warnings"`
+	ValidationErrors ValidationErrors `json:"validation_errors,omitempty"`
+}
+
+// DeploymentDriftReport lists differences between camunda and the metadata storage
+type DeploymentDriftReport struct {
+	Time              time.Time            `json:"time"`
+	MissingMetadata   []string             `json:"missing_metadata"`   //camunda deployment ids without stored metadata
+	MissingDeployment []string             `json:"missing_deployment"` //camunda deployment ids of stored metadata without camunda deployment
+	XmlMismatch       []DeploymentXmlDrift `json:"xml_mismatch"`       //deployments where the xml in camunda differs from the stored deployment
+	Repaired          []string             `json:"repaired"`
+	Errors            []string             `json:"errors"`
+}
+
+type DeploymentXmlDrift struct {
+	CamundaDeploymentId string `json:"camunda_deployment_id"`
+	DeploymentId        string `json:"deployment_id"`
+	ProcessDefinitionId string `json:"process_definition_id"`
+	ExpectedHash        string `json:"expected_hash"`
+	ActualHash          string `json:"actual_hash"`
+}
 
 type PathAndCharacteristic struct {
 	JsonPath         string `json:"json_path"`
@@ -34,3 +193,76 @@ type PathAndCharacteristic struct {
 }
 
 const UserId = "senergy"
+
+// ExternalTaskSummary is published periodically to spot missing or stuck external task workers
+type ExternalTaskSummary struct {
+	Time   time.Time                  `json:"time"`
+	Topics []ExternalTaskTopicSummary `json:"topics"`
+}
+
+type ExternalTaskTopicSummary struct {
+	Topic              string   `json:"topic"`
+	Waiting            int      `json:"waiting"`               //not locked or lock expired
+	Locked             int      `json:"locked"`                //locked by a worker
+	Failed             int      `json:"failed"`                //no retries left
+	OldestWaitingAgeMs int64    `json:"oldest_waiting_age_ms"` //age of the oldest waiting task since it was first seen by the client
+	ActiveWorkers      []string `json:"active_workers"`        //worker ids with valid locks
+}
+
+// KpiWindow aggregates the process instances of a deployment in one time window
+// stored in the deployment metadata to survive restarts
+type KpiWindow struct {
+	Start           time.Time `json:"start"`
+	Started         int64     `json:"started"`
+	Completed       int64     `json:"completed"`
+	Terminated      int64     `json:"terminated"`
+	Incidents       int64     `json:"incidents"`
+	DurationBuckets []int64   `json:"duration_buckets"` //instance counts by KpiDurationBucketsMs
+	DurationSumMs   int64     `json:"duration_sum_ms"`
+	DurationMaxMs   int64     `json:"duration_max_ms"`
+}
+
+// KpiDurationBucketsMs are the upper bounds of the duration histogram; the last bucket counts longer durations
+var KpiDurationBucketsMs = []int64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000, 900000, 1800000, 3600000, 21600000, 86400000}
+
+// KpiSummary is published after each kpi window
+type KpiSummary struct {
+	Time        time.Time       `json:"time"`
+	Deployments []DeploymentKpi `json:"deployments"`
+}
+
+// DeploymentKpi percentiles are estimated by the upper bound of the duration bucket
+type DeploymentKpi struct {
+	CamundaDeploymentId string    `json:"camunda_deployment_id"`
+	DeploymentId        string    `json:"deployment_id,omitempty"`
+	WindowStart         time.Time `json:"window_start"`
+	WindowEnd           time.Time `json:"window_end"`
+	Started             int64     `json:"started"`
+	Completed           int64     `json:"completed"`
+	Terminated          int64     `json:"terminated"`
+	Incidents           int64     `json:"incidents"`
+	DurationMeanMs      int64     `json:"duration_mean_ms,omitempty"`
+	DurationP50Ms       int64     `json:"duration_p50_ms,omitempty"`
+	DurationP90Ms       int64     `json:"duration_p90_ms,omitempty"`
+	DurationP99Ms       int64     `json:"duration_p99_ms,omitempty"`
+	DurationMaxMs       int64     `json:"duration_max_ms,omitempty"`
+}
+
+const (
+	StateSourceTrigger  = "trigger"   //database notifications and local timers
+	StateSourceFullSync = "full-sync" //periodic full update of all states
+	StateSourceCommand  = "command"   //results of cloud commands
+)
+
+// StateSchemaVersion is incremented on incompatible changes of state payloads
+const StateSchemaVersion = 1
+
+// StateEnvelope wraps state payloads if state_envelope is enabled
+// Seq is monotonic per gateway; it starts with the unix time in microseconds to stay monotonic across restarts
+type StateEnvelope struct {
+	Seq           int64       `json:"seq"`
+	Time          time.Time   `json:"time"`
+	Source        string      `json:"source"`
+	SchemaVersion int         `json:"schema_version"`
+	Payload       interface{} `json:"payload"`
+}
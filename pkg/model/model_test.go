@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	eventmodel "github.com/SENERGY-Platform/event-worker/pkg/model"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+func TestFogDeploymentMessageJsonShape(t *testing.T) {
+	original := model.DeploymentWithEventDesc{
+		Deployment: deploymentmodel.Deployment{
+			Id:      "d1",
+			Name:    "test",
+			Diagram: deploymentmodel.Diagram{XmlDeployed: "<xml/>", Svg: "<svg/>"},
+		},
+		EventDescriptions:  []eventmodel.EventDesc{{DeviceId: "did", ServiceId: "sid"}},
+		DeviceIdToLocalId:  map[string]string{"did": "ldid"},
+		ServiceIdToLocalId: map[string]string{"sid": "lsid"},
+	}
+	expected, err := json.Marshal(original)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	message := FogDeploymentMessage{}
+	err = json.Unmarshal(expected, &message)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	actual, err := json.Marshal(message)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var expectedMap, actualMap map[string]interface{}
+	json.Unmarshal(expected, &expectedMap)
+	json.Unmarshal(actual, &actualMap)
+	if !reflect.DeepEqual(expectedMap, actualMap) {
+		t.Error("\n", string(expected), "\n", string(actual))
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "strings"
+
+const (
+	ValidationRuleXml        = "xml"
+	ValidationRuleExecutable = "executable"
+	ValidationRuleUniqueId   = "unique_id"
+	ValidationRuleTaskTopic  = "task_topic"
+	ValidationRuleMessageRef = "message_ref"
+	ValidationRuleSignalRef  = "signal_ref"
+	ValidationRuleRewrite    = "rewrite"
+	ValidationRuleResource   = "resource"
+	ValidationRuleSchedule   = "schedule"
+	ValidationRulePolicy     = "business_key_policy"
+	ValidationRuleWatchdog   = "watchdog"
+	ValidationRuleVariable   = "synced_variable"
+)
+
+type ValidationError struct {
+	Rule      string `json:"rule"`
+	ElementId string `json:"element_id,omitempty"`
+	Message   string `json:"message"`
+}
+
+// ValidationErrors is returned by the deployment handling if the bpmn of a deployment is rejected
+type ValidationErrors []ValidationError
+
+func (this ValidationErrors) Error() string {
+	messages := []string{}
+	for _, e := range this {
+		msg := e.Rule + ": " + e.Message
+		if e.ElementId != "" {
+			msg = e.Rule + " (" + e.ElementId + "): " + e.Message
+		}
+		messages = append(messages, msg)
+	}
+	return "invalid deployment: " + strings.Join(messages, "; ")
+}
@@ -22,6 +22,7 @@ import (
 	"database/sql"
 	"github.com/lib/pq"
 	"log"
+	"strings"
 	"text/template"
 	"time"
 )
@@ -70,10 +71,27 @@ func registerNotifier(db *sql.DB, setChannel string, deleteChannel string, table
 		return err
 	}
 
+	notifyNew, notifyOld := "senergy_notify_new", "senergy_notify_old"
+	if strings.EqualFold(table, VariableTable) {
+		_, err = tx.Exec(notifyVariableNewFunctionSql)
+		if err != nil {
+			log.Println("ERROR: unable to create senergy_notify_variable_new", err)
+			return err
+		}
+		_, err = tx.Exec(notifyVariableOldFunctionSql)
+		if err != nil {
+			log.Println("ERROR: unable to create senergy_notify_variable_old", err)
+			return err
+		}
+		notifyNew, notifyOld = "senergy_notify_variable_new", "senergy_notify_variable_old"
+	}
+
 	triggerSql, err := templateToString(triggerTemplate, map[string]string{
 		"ChannelSet":    setChannel,
 		"ChannelDelete": deleteChannel,
 		"Table":         table,
+		"NotifyNew":     notifyNew,
+		"NotifyOld":     notifyOld,
 	})
 	if err != nil {
 		return err
@@ -127,6 +145,53 @@ end;
 $$;
 `
 
+// VariableTable is registered with notifier functions keeping the payload below the pg_notify limit of 8000 bytes
+const VariableTable = "ACT_HI_VARINST"
+
+// TruncatedField is set in notifications of VariableTable rows exceeding the pg_notify payload limit
+// the values TEXT_ and TEXT2_ are replaced by null in these notifications; the remaining columns are short
+const TruncatedField = "truncated_"
+
+const notifyVariableNewFunctionSql = `
+create or replace function senergy_notify_variable_new()
+ returns trigger
+ language plpgsql
+as $$
+declare
+  channel text := TG_ARGV[0];
+  payload text := row_to_json(NEW)::text;
+begin
+  IF octet_length(payload) >= 7900 THEN
+    payload := (to_jsonb(NEW) || '{"text_": null, "text2_": null, "` + TruncatedField + `": true}'::jsonb)::text;
+  END IF;
+  PERFORM (
+     select pg_notify(channel, payload)
+  );
+  RETURN NULL;
+end;
+$$;
+`
+
+const notifyVariableOldFunctionSql = `
+create or replace function senergy_notify_variable_old()
+ returns trigger
+ language plpgsql
+as $$
+declare
+  channel text := TG_ARGV[0];
+  payload text := row_to_json(OLD)::text;
+begin
+  IF octet_length(payload) >= 7900 THEN
+    payload := (to_jsonb(OLD) || '{"text_": null, "text2_": null, "` + TruncatedField + `": true}'::jsonb)::text;
+  END IF;
+  PERFORM (
+     select pg_notify(channel, payload)
+  );
+  RETURN NULL;
+end;
+$$;
+`
+
 const triggerTemplate = `
 DROP TRIGGER IF EXISTS notify_{{.Table}}_set
   ON {{.Table}};
@@ -138,13 +203,13 @@ CREATE TRIGGER notify_{{.Table}}_set
 AFTER INSERT OR UPDATE
 ON {{.Table}}
 FOR EACH ROW
-EXECUTE PROCEDURE senergy_notify_new('{{.ChannelSet}}');
+EXECUTE PROCEDURE {{.NotifyNew}}('{{.ChannelSet}}');
 
 CREATE TRIGGER notify_{{.Table}}_delete
 AFTER DELETE
 ON {{.Table}}
 FOR EACH ROW
-EXECUTE PROCEDURE senergy_notify_old('{{.ChannelDelete}}');
+EXECUTE PROCEDURE {{.NotifyOld}}('{{.ChannelDelete}}');
 `
 
 /*